@@ -62,6 +62,7 @@ func setIntents(st *state.State) {
 	st.AddIntents(gateway.IntentGuildMembers)
 	st.AddIntents(gateway.IntentGuildInvites)
 	st.AddIntents(gateway.IntentGuildMessages)
+	st.AddIntents(gateway.IntentGuildVoiceStates)
 }
 
 func setHandlers(st *state.State, h *router.Handler) {
@@ -75,4 +76,5 @@ func setHandlers(st *state.State, h *router.Handler) {
 	st.AddHandler(h.InteractionCreate)
 	st.AddHandler(h.MemberJoin)
 	st.AddHandler(h.MemberLeave)
+	st.AddHandler(h.VoiceStateUpdate)
 }
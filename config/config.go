@@ -30,6 +30,13 @@ type Config struct {
 		Key    string `env:"KEY"`
 		Secret string `env:"SECRET"`
 	} `env:",prefix=LASTFM_"`
+	Reps struct {
+		// ResetTimezone is the timezone whose midnight daily reps reset at.
+		ResetTimezone string `env:"RESET_TIMEZONE,default=UTC"`
+		// UserTimezones is whether daily reps reset at midnight in the
+		// timezone set by each user, if they have set one.
+		UserTimezones bool `env:"USER_TIMEZONES"`
+	} `env:",prefix=REP_"`
 	SushiiImageServer struct {
 		Host string `env:"HOST"`
 		Port int    `env:"PORT"`
@@ -14,6 +14,7 @@ import (
 	"github.com/twoscott/haseul-bot-2/database/reminderdb"
 	"github.com/twoscott/haseul-bot-2/database/repdb"
 	"github.com/twoscott/haseul-bot-2/database/rolesdb"
+	"github.com/twoscott/haseul-bot-2/database/userdb"
 	"github.com/twoscott/haseul-bot-2/database/youtubedb"
 )
 
@@ -29,6 +30,7 @@ type DB struct {
 	Reminders     *reminderdb.DB
 	Reps          *repdb.DB
 	Roles         *rolesdb.DB
+	Users         *userdb.DB
 	YouTube       *youtubedb.DB
 }
 
@@ -53,6 +55,7 @@ func GetInstance() *DB {
 			Reps:          repdb.New(dbConn),
 			Roles:         rolesdb.New(dbConn),
 			Levels:        levelsdb.New(dbConn),
+			Users:         userdb.New(dbConn),
 			YouTube:       youtubedb.New(dbConn),
 		}
 	})
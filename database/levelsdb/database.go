@@ -17,4 +17,12 @@ func New(dbConn *sqlx.DB) *DB {
 
 func (db *DB) createTables() {
 	db.MustExec(createUserXPTableQuery)
+	db.MustExec(addUserXPVoiceColumnQuery)
+	db.MustExec(createXPBucketsTableQuery)
+	db.MustExec(createLevelConfigsTableQuery)
+	db.MustExec(addLevelConfigXPColumnsQuery)
+	db.MustExec(addLevelConfigLeaderboardColumnsQuery)
+	db.MustExec(addLevelConfigCurveColumnsQuery)
+	db.MustExec(createRoleRewardsTableQuery)
+	db.MustExec(createXPRulesTableQuery)
 }
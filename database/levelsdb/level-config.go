@@ -0,0 +1,401 @@
+package levelsdb
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/lib/pq"
+)
+
+const defaultXPCooldown = 15
+
+const defaultLevelUpMessage levelUpText = "🎉 {user} has reached level {level}!"
+
+type levelUpText string
+
+// String returns the level up text as a string.
+func (t levelUpText) String() string {
+	return string(t)
+}
+
+// Format returns the level up text formatted according to the following
+// rules:
+// - {user} is replaced with the user mention of the member.
+// - {username} is replaced with the username of the member.
+// - {level} is replaced with the level the member reached.
+// - {server} is replaced with the name of the server.
+func (t levelUpText) Format(
+	member discord.Member, guild discord.Guild, level int) string {
+
+	text := t.String()
+
+	text = strings.ReplaceAll(text, "{user}", member.Mention())
+	text = strings.ReplaceAll(text, "{username}", member.User.DisplayOrUsername())
+	text = strings.ReplaceAll(text, "{level}", strconv.Itoa(level))
+	text = strings.ReplaceAll(text, "{server}", guild.Name)
+
+	return text
+}
+
+// LevelConfig represents the level settings of a guild.
+type LevelConfig struct {
+	GuildID discord.GuildID `db:"guildid"`
+	// Announce is whether level ups are announced in the guild.
+	Announce bool `db:"announce"`
+	// AnnounceChannelID is the channel level ups are announced in. If 0, level
+	// ups are announced in the channel the member levelled up in.
+	AnnounceChannelID  discord.ChannelID `db:"announcechannelid"`
+	RawAnnounceMessage levelUpText       `db:"announcemessage"`
+	// StackRewards is whether members keep the role rewards for lower levels
+	// when they are given the role reward for a higher level.
+	StackRewards bool `db:"stackrewards"`
+	// CooldownSeconds is how long after sending a message members earn
+	// reduced XP for their messages.
+	CooldownSeconds int32 `db:"cooldown"`
+	// WeekendMultiplier is the multiplier applied to XP earned on Saturdays
+	// and Sundays, in UTC.
+	WeekendMultiplier float64 `db:"weekendmultiplier"`
+	// LeaderboardChannelID is the channel the top members of the previous
+	// week and month are announced in when the period leaderboards reset. If
+	// 0, leaderboard resets are not announced.
+	LeaderboardChannelID discord.ChannelID `db:"leaderboardchannelid"`
+	// WeeklyResetAnnounced is the start of the latest week whose leaderboard
+	// reset has been announced.
+	WeeklyResetAnnounced *time.Time `db:"weeklyresetannounced"`
+	// MonthlyResetAnnounced is the start of the latest month whose
+	// leaderboard reset has been announced.
+	MonthlyResetAnnounced *time.Time `db:"monthlyresetannounced"`
+	// CurveType is the type of curve used to convert members' XP to levels.
+	CurveType LevelCurveType `db:"curve"`
+	// CurveXP is the XP required for each level on a linear level curve.
+	CurveXP int64 `db:"curvexp"`
+	// CurveTable is the total XP required to reach each level on a custom
+	// level curve, starting at level 1.
+	CurveTable pq.Int64Array `db:"curvetable"`
+}
+
+// DefaultLevelConfig returns the level config used by guilds that have not
+// configured their level settings.
+func DefaultLevelConfig(guildID discord.GuildID) LevelConfig {
+	return LevelConfig{
+		GuildID:           guildID,
+		StackRewards:      true,
+		CooldownSeconds:   defaultXPCooldown,
+		WeekendMultiplier: 1,
+	}
+}
+
+// Cooldown returns how long after sending a message members earn reduced XP
+// for their messages.
+func (c LevelConfig) Cooldown() time.Duration {
+	return time.Duration(c.CooldownSeconds) * time.Second
+}
+
+// Curve returns the curve used to convert members' XP to levels.
+func (c LevelConfig) Curve() LevelCurve {
+	switch c.CurveType {
+	case LinearLevelCurve:
+		return LinearCurve{XPPerLevel: c.CurveXP}
+	case QuadraticLevelCurve:
+		return QuadraticCurve{}
+	case TableLevelCurve:
+		return TableCurve{Thresholds: c.CurveTable}
+	default:
+		return LogCurve{}
+	}
+}
+
+// AnnounceMessage returns a level config's level up announcement message.
+func (c LevelConfig) AnnounceMessage() string {
+	if c.RawAnnounceMessage == "" {
+		return defaultLevelUpMessage.String()
+	}
+
+	return c.RawAnnounceMessage.String()
+}
+
+// FormattedAnnounceMessage returns a level config's level up announcement
+// message, formatted with the details of the member and the level reached.
+func (c LevelConfig) FormattedAnnounceMessage(
+	member discord.Member, guild discord.Guild, level int) string {
+
+	if c.RawAnnounceMessage == "" {
+		return defaultLevelUpMessage.Format(member, guild, level)
+	}
+
+	return c.RawAnnounceMessage.Format(member, guild, level)
+}
+
+const (
+	createLevelConfigsTableQuery = `
+		CREATE TABLE IF NOT EXISTS LevelConfigs(
+			guildID               INT8          NOT NULL,
+			announce              BOOLEAN       NOT NULL DEFAULT FALSE,
+			announceChannelID     INT8          NOT NULL DEFAULT 0,
+			announceMessage       VARCHAR(1024) NOT NULL DEFAULT '',
+			stackRewards          BOOLEAN       NOT NULL DEFAULT TRUE,
+			cooldown              INT4          NOT NULL DEFAULT 15,
+			weekendMultiplier     REAL          NOT NULL DEFAULT 1,
+			leaderboardChannelID  INT8          NOT NULL DEFAULT 0,
+			weeklyResetAnnounced  TIMESTAMP,
+			monthlyResetAnnounced TIMESTAMP,
+			curve                 INT2          NOT NULL DEFAULT 0,
+			curveXP               INT8          NOT NULL DEFAULT 0,
+			curveTable            INT8[]        NOT NULL DEFAULT '{}',
+			PRIMARY KEY(guildID)
+		)`
+	addLevelConfigXPColumnsQuery = `
+		ALTER TABLE LevelConfigs
+		ADD COLUMN IF NOT EXISTS cooldown INT4 NOT NULL DEFAULT 15,
+		ADD COLUMN IF NOT EXISTS weekendMultiplier REAL NOT NULL DEFAULT 1`
+	addLevelConfigLeaderboardColumnsQuery = `
+		ALTER TABLE LevelConfigs
+		ADD COLUMN IF NOT EXISTS leaderboardChannelID INT8 NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS weeklyResetAnnounced TIMESTAMP,
+		ADD COLUMN IF NOT EXISTS monthlyResetAnnounced TIMESTAMP`
+	addLevelConfigCurveColumnsQuery = `
+		ALTER TABLE LevelConfigs
+		ADD COLUMN IF NOT EXISTS curve INT2 NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS curveXP INT8 NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS curveTable INT8[] NOT NULL DEFAULT '{}'`
+	setAnnounceChannelQuery = `
+		INSERT INTO LevelConfigs(guildID, announce, announceChannelID)
+		VALUES($1, TRUE, $2)
+		ON CONFLICT(guildID) DO UPDATE 
+		SET announce = TRUE, announceChannelID = $2`
+	setAnnounceMessageQuery = `
+		INSERT INTO LevelConfigs(guildID, announceMessage) VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET announceMessage = $2`
+	disableAnnouncementsQuery = `
+		UPDATE LevelConfigs SET announce = FALSE
+		WHERE guildID = $1 AND announce`
+	setStackRewardsQuery = `
+		INSERT INTO LevelConfigs(guildID, stackRewards) VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET stackRewards = $2`
+	setXPCooldownQuery = `
+		INSERT INTO LevelConfigs(guildID, cooldown) VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET cooldown = $2`
+	setWeekendMultiplierQuery = `
+		INSERT INTO LevelConfigs(guildID, weekendMultiplier) VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET weekendMultiplier = $2`
+	setLeaderboardChannelQuery = `
+		INSERT INTO LevelConfigs(
+			guildID,
+			leaderboardChannelID,
+			weeklyResetAnnounced,
+			monthlyResetAnnounced
+		)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT(guildID) DO UPDATE
+		SET leaderboardChannelID = $2,
+			weeklyResetAnnounced = $3,
+			monthlyResetAnnounced = $4`
+	setLevelCurveQuery = `
+		INSERT INTO LevelConfigs(guildID, curve, curveXP, curveTable)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT(guildID) DO UPDATE
+		SET curve = $2, curveXP = $3, curveTable = $4`
+	disableLeaderboardResetsQuery = `
+		UPDATE LevelConfigs SET leaderboardChannelID = 0
+		WHERE guildID = $1 AND leaderboardChannelID != 0`
+	setWeeklyResetAnnouncedQuery = `
+		UPDATE LevelConfigs SET weeklyResetAnnounced = $2 WHERE guildID = $1`
+	setMonthlyResetAnnouncedQuery = `
+		UPDATE LevelConfigs SET monthlyResetAnnounced = $2 WHERE guildID = $1`
+	getLeaderboardResetConfigsQuery = `
+		SELECT * FROM LevelConfigs WHERE leaderboardChannelID != 0`
+	getLevelConfigQuery = `SELECT * FROM LevelConfigs WHERE guildID = $1`
+)
+
+// SetAnnounceChannel enables level up announcements for a guild and sets
+// the channel they are announced in. A channel ID of 0 announces level ups in
+// the channel the member levelled up in.
+func (db *DB) SetAnnounceChannel(
+	guildID discord.GuildID, channelID discord.ChannelID) (bool, error) {
+
+	res, err := db.Exec(setAnnounceChannelQuery, guildID, channelID)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// SetAnnounceMessage sets the level up announcement message for a guild.
+func (db *DB) SetAnnounceMessage(
+	guildID discord.GuildID, message string) (bool, error) {
+
+	res, err := db.Exec(setAnnounceMessageQuery, guildID, message)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// DisableAnnouncements disables level up announcements for a guild.
+func (db *DB) DisableAnnouncements(guildID discord.GuildID) (bool, error) {
+	res, err := db.Exec(disableAnnouncementsQuery, guildID)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	return updated > 0, err
+}
+
+// SetStackRewards sets whether members keep the role rewards for lower
+// levels in a guild.
+func (db *DB) SetStackRewards(
+	guildID discord.GuildID, stack bool) (bool, error) {
+
+	res, err := db.Exec(setStackRewardsQuery, guildID, stack)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// SetXPCooldown sets how many seconds after sending a message members of a
+// guild earn reduced XP for their messages.
+func (db *DB) SetXPCooldown(
+	guildID discord.GuildID, seconds int32) (bool, error) {
+
+	res, err := db.Exec(setXPCooldownQuery, guildID, seconds)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// SetWeekendMultiplier sets the multiplier applied to XP earned in a guild on
+// weekends.
+func (db *DB) SetWeekendMultiplier(
+	guildID discord.GuildID, multiplier float64) (bool, error) {
+
+	res, err := db.Exec(setWeekendMultiplierQuery, guildID, multiplier)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// SetLevelCurve sets the curve used to convert members' XP to levels in a
+// guild. xpPerLevel is only used by linear curves, and table is only used by
+// custom curves.
+func (db *DB) SetLevelCurve(
+	guildID discord.GuildID,
+	curveType LevelCurveType,
+	xpPerLevel int64,
+	table []int64) (bool, error) {
+
+	if table == nil {
+		table = []int64{}
+	}
+
+	res, err := db.Exec(
+		setLevelCurveQuery,
+		guildID,
+		curveType,
+		xpPerLevel,
+		pq.Int64Array(table),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// SetLeaderboardChannel sets the channel leaderboard resets are announced in
+// for a guild, along with the starts of the current week and month, so that
+// resets are announced from the next period onwards.
+func (db *DB) SetLeaderboardChannel(
+	guildID discord.GuildID,
+	channelID discord.ChannelID,
+	weekStart, monthStart time.Time) (bool, error) {
+
+	res, err := db.Exec(
+		setLeaderboardChannelQuery,
+		guildID,
+		channelID,
+		weekStart.UTC(),
+		monthStart.UTC(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// DisableLeaderboardResets stops leaderboard resets from being announced in
+// a guild.
+func (db *DB) DisableLeaderboardResets(guildID discord.GuildID) (bool, error) {
+	res, err := db.Exec(disableLeaderboardResetsQuery, guildID)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	return updated > 0, err
+}
+
+// SetWeeklyResetAnnounced sets the start of the latest week whose leaderboard
+// reset has been announced in a guild.
+func (db *DB) SetWeeklyResetAnnounced(
+	guildID discord.GuildID, weekStart time.Time) (bool, error) {
+
+	res, err := db.Exec(setWeeklyResetAnnouncedQuery, guildID, weekStart.UTC())
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// SetMonthlyResetAnnounced sets the start of the latest month whose
+// leaderboard reset has been announced in a guild.
+func (db *DB) SetMonthlyResetAnnounced(
+	guildID discord.GuildID, monthStart time.Time) (bool, error) {
+
+	res, err := db.Exec(
+		setMonthlyResetAnnouncedQuery, guildID, monthStart.UTC(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// GetLeaderboardResetConfigs returns the level configs of all guilds that
+// announce leaderboard resets.
+func (db *DB) GetLeaderboardResetConfigs() ([]LevelConfig, error) {
+	var configs []LevelConfig
+	err := db.Select(&configs, getLeaderboardResetConfigsQuery)
+
+	return configs, err
+}
+
+// GetLevelConfig returns the level config for a guild.
+func (db *DB) GetLevelConfig(guildID discord.GuildID) (*LevelConfig, error) {
+	var config LevelConfig
+	err := db.Get(&config, getLevelConfigQuery, guildID)
+
+	return &config, err
+}
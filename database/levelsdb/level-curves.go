@@ -0,0 +1,164 @@
+package levelsdb
+
+import (
+	"math"
+	"sort"
+)
+
+// LevelCurveType is the type of curve a guild uses to convert XP to levels.
+type LevelCurveType int16
+
+const (
+	LogLevelCurve LevelCurveType = iota
+	LinearLevelCurve
+	QuadraticLevelCurve
+	TableLevelCurve
+)
+
+// String returns the name of the level curve type.
+func (t LevelCurveType) String() string {
+	switch t {
+	case LinearLevelCurve:
+		return "Linear"
+	case QuadraticLevelCurve:
+		return "Quadratic"
+	case TableLevelCurve:
+		return "Custom"
+	default:
+		return "Logarithmic"
+	}
+}
+
+const defaultLinearCurveXP = 1000
+
+// LevelCurve converts between XP and levels.
+type LevelCurve interface {
+	// Level returns the level reached with the provided XP.
+	Level(xp int64) int
+	// RequiredXP returns the total XP required to reach a level.
+	RequiredXP(level int) int64
+}
+
+// DefaultLevelCurve is the level curve used for global levels and by guilds
+// that have not chosen a level curve.
+var DefaultLevelCurve LevelCurve = LogCurve{}
+
+const (
+	logOffset   = 100_000
+	logModifier = 200
+)
+
+// LogCurve is a logarithmic level curve, where each level requires slightly
+// more XP than the last.
+type LogCurve struct{}
+
+func (LogCurve) Level(xp int64) int {
+	return int(math.Log10(float64(xp+logOffset)/logOffset) * logModifier)
+}
+
+func (LogCurve) RequiredXP(level int) int64 {
+	exponent := float64(level) / logModifier
+	return int64(math.Ceil(math.Pow(10, exponent)*logOffset)) - logOffset
+}
+
+// LinearCurve is a linear level curve, where every level requires the same
+// amount of XP.
+type LinearCurve struct {
+	XPPerLevel int64
+}
+
+func (c LinearCurve) Level(xp int64) int {
+	return int(xp / c.xpPerLevel())
+}
+
+func (c LinearCurve) RequiredXP(level int) int64 {
+	return int64(level) * c.xpPerLevel()
+}
+
+func (c LinearCurve) xpPerLevel() int64 {
+	if c.XPPerLevel < 1 {
+		return defaultLinearCurveXP
+	}
+
+	return c.XPPerLevel
+}
+
+// QuadraticCurve is a quadratic level curve, where going from level n to
+// level n+1 requires 5n² + 50n + 100 XP, as used by MEE6.
+type QuadraticCurve struct{}
+
+func (c QuadraticCurve) Level(xp int64) int {
+	return levelFromRequiredXP(c, xp)
+}
+
+func (QuadraticCurve) RequiredXP(level int) int64 {
+	l := int64(level)
+	return 5*(l-1)*l*(2*l-1)/6 + 25*(l-1)*l + 100*l
+}
+
+// TableCurve is a custom level curve, where Thresholds holds the total XP
+// required to reach each level, starting at level 1. Levels past the end of
+// the table each require the same XP as the last level in the table.
+type TableCurve struct {
+	Thresholds []int64
+}
+
+func (c TableCurve) Level(xp int64) int {
+	size := len(c.Thresholds)
+	if size < 1 {
+		return DefaultLevelCurve.Level(xp)
+	}
+
+	last := c.Thresholds[size-1]
+	if xp >= last {
+		return size + int((xp-last)/c.lastStep())
+	}
+
+	return sort.Search(size, func(i int) bool {
+		return c.Thresholds[i] > xp
+	})
+}
+
+func (c TableCurve) RequiredXP(level int) int64 {
+	size := len(c.Thresholds)
+	if size < 1 {
+		return DefaultLevelCurve.RequiredXP(level)
+	}
+	if level < 1 {
+		return 0
+	}
+	if level <= size {
+		return c.Thresholds[level-1]
+	}
+
+	return c.Thresholds[size-1] + int64(level-size)*c.lastStep()
+}
+
+// lastStep returns the XP required to go from the second last to the last
+// level in the table.
+func (c TableCurve) lastStep() int64 {
+	size := len(c.Thresholds)
+
+	step := c.Thresholds[size-1]
+	if size > 1 {
+		step -= c.Thresholds[size-2]
+	}
+	if step < 1 {
+		return 1
+	}
+
+	return step
+}
+
+// levelFromRequiredXP returns the level reached with the provided XP on a
+// curve by searching for the highest level whose required XP is met.
+func levelFromRequiredXP(curve LevelCurve, xp int64) int {
+	high := 1
+	for curve.RequiredXP(high) <= xp {
+		high *= 2
+	}
+
+	return sort.Search(high, func(level int) bool {
+		return curve.RequiredXP(level) > xp
+	}) - 1
+}
@@ -1,24 +1,6 @@
 package levelsdb
 
-import (
-	"math"
-
-	"github.com/diamondburned/arikawa/v3/discord"
-)
-
-const (
-	logOffset   = 100_000
-	logModifier = 200
-	logBase     = 10
-)
-
-func getLevelFromXP(xp int64) int {
-	return int(math.Log10(float64(xp+logOffset)/logOffset) * logModifier)
-}
-
-func getRequiredXPForLevel(level int) int64 {
-	return int64(math.Pow10(level/logModifier)*logOffset) - logOffset
-}
+import "github.com/diamondburned/arikawa/v3/discord"
 
 type Progress struct {
 	XP           int64
@@ -29,17 +11,23 @@ type Progress struct {
 type UserXP struct {
 	UserID discord.UserID `db:"userid"`
 	XP     int64          `db:"xp"`
+	// VoiceSeconds is the time the user has spent earning XP in voice
+	// channels.
+	VoiceSeconds int64 `db:"voiceseconds"`
 }
 
-func (u UserXP) Level() int {
-	return getLevelFromXP(u.XP)
+// Level returns the level the user has reached on the provided level curve.
+func (u UserXP) Level(curve LevelCurve) int {
+	return curve.Level(u.XP)
 }
 
-func (u UserXP) Progress() Progress {
-	level := u.Level()
+// Progress returns the user's progress towards their next level on the
+// provided level curve.
+func (u UserXP) Progress(curve LevelCurve) Progress {
+	level := u.Level(curve)
 
-	baseXP := getRequiredXPForLevel(level)
-	nextXP := getRequiredXPForLevel(level + 1)
+	baseXP := curve.RequiredXP(level)
+	nextXP := curve.RequiredXP(level + 1)
 
 	xpProgress := u.XP - baseXP
 
@@ -58,27 +46,55 @@ type GuildUserXP struct {
 const (
 	createUserXPTableQuery = `
 		CREATE TABLE IF NOT EXISTS UserXP(
-			guildID INT8 NOT NULL,
-			userID  INT8 NOT NULL,
-			xp      INT8 NOT NULL DEFAULT 0,
+			guildID      INT8 NOT NULL,
+			userID       INT8 NOT NULL,
+			xp           INT8 NOT NULL DEFAULT 0,
+			voiceSeconds INT8 NOT NULL DEFAULT 0,
 			PRIMARY KEY(guildID, userID)
 		)`
+	addUserXPVoiceColumnQuery = `
+		ALTER TABLE UserXP
+		ADD COLUMN IF NOT EXISTS voiceSeconds INT8 NOT NULL DEFAULT 0`
 	addUserXPQuery = `
+		WITH bucket AS (
+			INSERT INTO UserXPBuckets
+			VALUES($1, $2, (NOW() AT TIME ZONE 'UTC')::DATE, $3)
+			ON CONFLICT(guildID, userID, day) DO UPDATE
+			SET xp = UserXPBuckets.xp + $3
+		)
 		INSERT INTO UserXP VALUES($1, $2, $3)
 		ON CONFLICT(guildID, userID) DO UPDATE SET xp = UserXP.xp + $3
 		RETURNING xp`
+	addUserVoiceXPQuery = `
+		WITH bucket AS (
+			INSERT INTO UserXPBuckets
+			VALUES($1, $2, (NOW() AT TIME ZONE 'UTC')::DATE, $3)
+			ON CONFLICT(guildID, userID, day) DO UPDATE
+			SET xp = UserXPBuckets.xp + $3
+		)
+		INSERT INTO UserXP VALUES($1, $2, $3, $4)
+		ON CONFLICT(guildID, userID) DO UPDATE
+		SET xp = UserXP.xp + $3, voiceSeconds = UserXP.voiceSeconds + $4
+		RETURNING xp`
+	getUserStatsQuery = `
+		SELECT userID, xp, voiceSeconds FROM UserXP
+		WHERE guildID = $1 AND userID = $2`
+	getUserGlobalStatsQuery = `
+		SELECT userID, SUM(xp) AS xp, SUM(voiceSeconds) AS voiceSeconds
+		FROM UserXP WHERE userID = $1
+		GROUP BY userID`
 	getUserXPQuery = `
 		SELECT xp FROM UserXP WHERE guildID = $1 AND userID = $2`
 	getGlobalXPQuery = `
 		SELECT SUM(xp) FROM UserXP WHERE userID = $1`
 	getTopUsersQuery = `
 		SELECT * FROM UserXP WHERE guildID = $1
-		ORDER BY xp DESC
+		ORDER BY xp DESC, userID
 		LIMIT $2`
 	getTopGlobalUsersQuery = `
 		SELECT userID, SUM(xp) AS xp FROM UserXP
 		GROUP BY userID 
-		ORDER BY xp DESC 
+		ORDER BY xp DESC, userID
 		LIMIT $1`
 	getEntriesSizeQuery = `
 		SELECT COUNT(userID) FROM UserXP 
@@ -87,7 +103,8 @@ const (
 		SELECT COUNT(DISTINCT userID) FROM UserXP`
 )
 
-// AddUserXP XP for a user in a guild.
+// AddUserXP XP for a user in a guild, recording the XP in the current day's
+// XP bucket.
 func (db *DB) AddUserXP(
 	guildID discord.GuildID,
 	userID discord.UserID,
@@ -96,6 +113,38 @@ func (db *DB) AddUserXP(
 	return xp, db.Get(&xp, addUserXPQuery, guildID, userID, xpAmount)
 }
 
+// AddUserVoiceXP adds XP earned in voice channels for a user in a guild,
+// along with the time spent earning it.
+func (db *DB) AddUserVoiceXP(
+	guildID discord.GuildID,
+	userID discord.UserID,
+	xpAmount int64,
+	voiceSeconds int64) (xp int64, err error) {
+
+	return xp, db.Get(
+		&xp, addUserVoiceXPQuery, guildID, userID, xpAmount, voiceSeconds,
+	)
+}
+
+// GetUserStats returns the XP and voice time for a user in a guild.
+func (db *DB) GetUserStats(
+	guildID discord.GuildID, userID discord.UserID) (*UserXP, error) {
+
+	var stats UserXP
+	err := db.Get(&stats, getUserStatsQuery, guildID, userID)
+
+	return &stats, err
+}
+
+// GetUserGlobalStats returns the XP and voice time for a user across all
+// guilds.
+func (db *DB) GetUserGlobalStats(userID discord.UserID) (*UserXP, error) {
+	var stats UserXP
+	err := db.Get(&stats, getUserGlobalStatsQuery, userID)
+
+	return &stats, err
+}
+
 // GetUserXP returns the XP for a user in a guild.
 func (db *DB) GetUserXP(
 	guildID discord.GuildID, userID discord.UserID) (xp int64, err error) {
@@ -0,0 +1,157 @@
+package levelsdb
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// UserRank is a user's position on a leaderboard, out of the total number of
+// users on the leaderboard.
+type UserRank struct {
+	Rank  int64 `db:"rank"`
+	Total int64 `db:"total"`
+}
+
+// RankedUserXP is a user's XP along with their position on a leaderboard.
+type RankedUserXP struct {
+	UserXP `db:""`
+	Rank   int64 `db:"rank"`
+}
+
+// Users are ranked by ROW_NUMBER with ties broken by user ID, matching the
+// order of the top users queries so that ranks agree with leaderboard
+// positions.
+const (
+	getUserRankQuery = `
+		SELECT rank, total FROM (
+			SELECT userID,
+				ROW_NUMBER() OVER (ORDER BY xp DESC, userID) AS rank,
+				COUNT(*) OVER () AS total
+			FROM UserXP WHERE guildID = $1
+		) AS ranked
+		WHERE userID = $2`
+	getUserGlobalRankQuery = `
+		SELECT rank, total FROM (
+			SELECT userID,
+				ROW_NUMBER() OVER (ORDER BY SUM(xp) DESC, userID) AS rank,
+				COUNT(*) OVER () AS total
+			FROM UserXP GROUP BY userID
+		) AS ranked
+		WHERE userID = $1`
+	getUserRankBetweenQuery = `
+		SELECT rank, total FROM (
+			SELECT userID,
+				ROW_NUMBER() OVER (ORDER BY SUM(xp) DESC, userID) AS rank,
+				COUNT(*) OVER () AS total
+			FROM UserXPBuckets
+			WHERE guildID = $1
+			AND day >= ($3::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+			AND day < ($4::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+			GROUP BY userID
+		) AS ranked
+		WHERE userID = $2`
+	getUserGlobalRankBetweenQuery = `
+		SELECT rank, total FROM (
+			SELECT userID,
+				ROW_NUMBER() OVER (ORDER BY SUM(xp) DESC, userID) AS rank,
+				COUNT(*) OVER () AS total
+			FROM UserXPBuckets
+			WHERE day >= ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+			AND day < ($3::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+			GROUP BY userID
+		) AS ranked
+		WHERE userID = $1`
+	getUserNeighboursQuery = `
+		WITH ranked AS (
+			SELECT userID, xp, voiceSeconds,
+				ROW_NUMBER() OVER (ORDER BY xp DESC, userID) AS rank
+			FROM UserXP WHERE guildID = $1
+		)
+		SELECT ranked.* FROM ranked, (
+			SELECT rank FROM ranked WHERE userID = $2
+		) AS target
+		WHERE ranked.rank BETWEEN target.rank - $3 AND target.rank + $3
+		ORDER BY ranked.rank`
+	getUserGlobalNeighboursQuery = `
+		WITH ranked AS (
+			SELECT userID, SUM(xp) AS xp, SUM(voiceSeconds) AS voiceSeconds,
+				ROW_NUMBER() OVER (ORDER BY SUM(xp) DESC, userID) AS rank
+			FROM UserXP GROUP BY userID
+		)
+		SELECT ranked.* FROM ranked, (
+			SELECT rank FROM ranked WHERE userID = $1
+		) AS target
+		WHERE ranked.rank BETWEEN target.rank - $2 AND target.rank + $2
+		ORDER BY ranked.rank`
+)
+
+// GetUserRank returns the rank position of a user in a guild, out of the
+// total number of users in the guild with XP.
+func (db *DB) GetUserRank(
+	guildID discord.GuildID, userID discord.UserID) (*UserRank, error) {
+
+	var rank UserRank
+	err := db.Get(&rank, getUserRankQuery, guildID, userID)
+
+	return &rank, err
+}
+
+// GetUserGlobalRank returns the global rank position of a user by their total
+// XP, out of the total number of users with XP.
+func (db *DB) GetUserGlobalRank(userID discord.UserID) (*UserRank, error) {
+	var rank UserRank
+	err := db.Get(&rank, getUserGlobalRankQuery, userID)
+
+	return &rank, err
+}
+
+// GetUserRankBetween returns the rank position of a user in a guild by the XP
+// they earned between the start and end dates, out of the total number of
+// users in the guild who earned XP in that time.
+func (db *DB) GetUserRankBetween(
+	guildID discord.GuildID,
+	userID discord.UserID,
+	start, end time.Time) (*UserRank, error) {
+
+	var rank UserRank
+	err := db.Get(
+		&rank, getUserRankBetweenQuery, guildID, userID, start, end,
+	)
+
+	return &rank, err
+}
+
+// GetUserGlobalRankBetween returns the global rank position of a user by the
+// XP they earned between the start and end dates, out of the total number of
+// users who earned XP in that time.
+func (db *DB) GetUserGlobalRankBetween(
+	userID discord.UserID, start, end time.Time) (*UserRank, error) {
+
+	var rank UserRank
+	err := db.Get(&rank, getUserGlobalRankBetweenQuery, userID, start, end)
+
+	return &rank, err
+}
+
+// GetUserNeighbours returns a user and the users ranked up to distance
+// positions above and below them in a guild, ordered by rank.
+func (db *DB) GetUserNeighbours(
+	guildID discord.GuildID,
+	userID discord.UserID,
+	distance int64) (users []RankedUserXP, err error) {
+
+	return users, db.Select(
+		&users, getUserNeighboursQuery, guildID, userID, distance,
+	)
+}
+
+// GetUserGlobalNeighbours returns a user and the users ranked up to distance
+// positions above and below them globally, ordered by rank.
+func (db *DB) GetUserGlobalNeighbours(
+	userID discord.UserID, distance int64) (users []RankedUserXP, err error) {
+
+	return users, db.Select(
+		&users, getUserGlobalNeighboursQuery, userID, distance,
+	)
+}
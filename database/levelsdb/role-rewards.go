@@ -0,0 +1,66 @@
+package levelsdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+// RoleReward represents a role given to members when they reach a level.
+type RoleReward struct {
+	GuildID discord.GuildID `db:"guildid"`
+	Level   int32           `db:"level"`
+	RoleID  discord.RoleID  `db:"roleid"`
+}
+
+const (
+	createRoleRewardsTableQuery = `
+		CREATE TABLE IF NOT EXISTS LevelRoleRewards(
+			guildID INT8 NOT NULL,
+			level   INT4 NOT NULL,
+			roleID  INT8 NOT NULL,
+			PRIMARY KEY(guildID, roleID)
+		)`
+	addRoleRewardQuery = `
+		INSERT INTO LevelRoleRewards VALUES($1, $2, $3)
+		ON CONFLICT(guildID, roleID) DO UPDATE SET level = $2`
+	removeRoleRewardQuery = `
+		DELETE FROM LevelRoleRewards WHERE guildID = $1 AND roleID = $2`
+	getRoleRewardsQuery = `
+		SELECT * FROM LevelRoleRewards WHERE guildID = $1
+		ORDER BY level, roleID`
+)
+
+// AddRoleReward sets a role to be given to members of a guild when they reach
+// a level, replacing the level the role was previously given at.
+func (db *DB) AddRoleReward(
+	guildID discord.GuildID,
+	level int32,
+	roleID discord.RoleID) (bool, error) {
+
+	res, err := db.Exec(addRoleRewardQuery, guildID, level, roleID)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// RemoveRoleReward removes a role from being given to members of a guild when
+// they reach a level.
+func (db *DB) RemoveRoleReward(
+	guildID discord.GuildID, roleID discord.RoleID) (bool, error) {
+
+	res, err := db.Exec(removeRoleRewardQuery, guildID, roleID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	return removed > 0, err
+}
+
+// GetRoleRewards returns all the role rewards for a guild, ordered by level.
+func (db *DB) GetRoleRewards(guildID discord.GuildID) ([]RoleReward, error) {
+	var rewards []RoleReward
+	err := db.Select(&rewards, getRoleRewardsQuery, guildID)
+
+	return rewards, err
+}
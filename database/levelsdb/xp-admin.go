@@ -0,0 +1,150 @@
+package levelsdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+const (
+	setUserXPQuery = `
+		INSERT INTO UserXP VALUES($1, $2, $3)
+		ON CONFLICT(guildID, userID) DO UPDATE SET xp = $3`
+	adjustUserXPQuery = `
+		INSERT INTO UserXP VALUES($1, $2, GREATEST($3, 0))
+		ON CONFLICT(guildID, userID) DO UPDATE
+		SET xp = GREATEST(UserXP.xp + $3, 0)
+		RETURNING xp`
+	importAddUserXPQuery = `
+		INSERT INTO UserXP VALUES($1, $2, $3)
+		ON CONFLICT(guildID, userID) DO UPDATE SET xp = UserXP.xp + $3`
+	resetUserXPQuery = `
+		DELETE FROM UserXP WHERE guildID = $1 AND userID = $2`
+	resetUserXPBucketsQuery = `
+		DELETE FROM UserXPBuckets WHERE guildID = $1 AND userID = $2`
+	resetGuildXPQuery = `
+		DELETE FROM UserXP WHERE guildID = $1`
+	resetGuildXPBucketsQuery = `
+		DELETE FROM UserXPBuckets WHERE guildID = $1`
+)
+
+// SetUserXP sets the XP for a user in a guild.
+func (db *DB) SetUserXP(
+	guildID discord.GuildID,
+	userID discord.UserID,
+	xp int64) (bool, error) {
+
+	res, err := db.Exec(setUserXPQuery, guildID, userID, xp)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// AdjustUserXP adds XP to, or removes XP from, a user in a guild without
+// counting towards the period leaderboards, and returns the user's new XP.
+// XP cannot be reduced below 0.
+func (db *DB) AdjustUserXP(
+	guildID discord.GuildID,
+	userID discord.UserID,
+	xpAmount int64) (xp int64, err error) {
+
+	return xp, db.Get(&xp, adjustUserXPQuery, guildID, userID, xpAmount)
+}
+
+// ImportUserXP sets the XP for many users in a guild at once, or adds to
+// their existing XP if add is true, returning the number of users imported.
+func (db *DB) ImportUserXP(
+	guildID discord.GuildID, users []UserXP, add bool) (int64, error) {
+
+	query := setUserXPQuery
+	if add {
+		query = importAddUserXPQuery
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var imported int64
+	for _, u := range users {
+		res, err := stmt.Exec(guildID, u.UserID, u.XP)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		imported += rows
+	}
+
+	return imported, tx.Commit()
+}
+
+// ResetUserXP removes all XP for a user in a guild.
+func (db *DB) ResetUserXP(
+	guildID discord.GuildID, userID discord.UserID) (bool, error) {
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(resetUserXPQuery, guildID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(resetUserXPBucketsQuery, guildID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, tx.Commit()
+}
+
+// ResetGuildXP removes all XP for every user in a guild, returning the
+// number of users reset.
+func (db *DB) ResetGuildXP(guildID discord.GuildID) (int64, error) {
+	tx, err := db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(resetGuildXPQuery, guildID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(resetGuildXPBucketsQuery, guildID)
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return removed, tx.Commit()
+}
@@ -0,0 +1,94 @@
+package levelsdb
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+const (
+	createXPBucketsTableQuery = `
+		CREATE TABLE IF NOT EXISTS UserXPBuckets(
+			guildID INT8 NOT NULL,
+			userID  INT8 NOT NULL,
+			day     DATE NOT NULL,
+			xp      INT8 NOT NULL DEFAULT 0,
+			PRIMARY KEY(guildID, userID, day)
+		)`
+	getTopUsersBetweenQuery = `
+		SELECT userID, SUM(xp) AS xp FROM UserXPBuckets
+		WHERE guildID = $1
+		AND day >= ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+		AND day < ($3::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+		GROUP BY userID
+		ORDER BY xp DESC, userID
+		LIMIT $4`
+	getTopGlobalUsersBetweenQuery = `
+		SELECT userID, SUM(xp) AS xp FROM UserXPBuckets
+		WHERE day >= ($1::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+		AND day < ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+		GROUP BY userID
+		ORDER BY xp DESC, userID
+		LIMIT $3`
+	getEntriesSizeBetweenQuery = `
+		SELECT COUNT(DISTINCT userID) FROM UserXPBuckets
+		WHERE guildID = $1
+		AND day >= ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+		AND day < ($3::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE`
+	getGlobalEntriesSizeBetweenQuery = `
+		SELECT COUNT(DISTINCT userID) FROM UserXPBuckets
+		WHERE day >= ($1::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+		AND day < ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE`
+	deleteXPBucketsBeforeQuery = `
+		DELETE FROM UserXPBuckets WHERE day < ($1::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE`
+)
+
+// GetTopUsersBetween returns the users in a guild who earned the most XP
+// between the start and end dates, with the XP they earned in that time.
+func (db *DB) GetTopUsersBetween(
+	guildID discord.GuildID,
+	start, end time.Time,
+	limit int64) (users []UserXP, err error) {
+
+	return users, db.Select(
+		&users, getTopUsersBetweenQuery, guildID, start, end, limit,
+	)
+}
+
+// GetTopGlobalUsersBetween returns the users who earned the most XP across
+// all guilds between the start and end dates, with the XP they earned in that
+// time.
+func (db *DB) GetTopGlobalUsersBetween(
+	start, end time.Time, limit int64) (users []UserXP, err error) {
+
+	return users, db.Select(
+		&users, getTopGlobalUsersBetweenQuery, start, end, limit,
+	)
+}
+
+// GetEntriesSizeBetween returns the number of users in a guild who earned XP
+// between the start and end dates.
+func (db *DB) GetEntriesSizeBetween(
+	guildID discord.GuildID, start, end time.Time) (size int64, err error) {
+
+	return size, db.Get(&size, getEntriesSizeBetweenQuery, guildID, start, end)
+}
+
+// GetGlobalEntriesSizeBetween returns the number of users who earned XP
+// between the start and end dates.
+func (db *DB) GetGlobalEntriesSizeBetween(
+	start, end time.Time) (size int64, err error) {
+
+	return size, db.Get(&size, getGlobalEntriesSizeBetweenQuery, start, end)
+}
+
+// DeleteXPBucketsBefore deletes the XP earned before the provided date from
+// the XP buckets, returning the number of buckets deleted.
+func (db *DB) DeleteXPBucketsBefore(day time.Time) (int64, error) {
+	res, err := db.Exec(deleteXPBucketsBeforeQuery, day)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
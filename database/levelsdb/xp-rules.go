@@ -0,0 +1,78 @@
+package levelsdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+// XPRuleType is the type of target an XP rule applies to.
+type XPRuleType int16
+
+const (
+	ChannelXPRule XPRuleType = iota + 1
+	RoleXPRule
+)
+
+// XPRule represents a multiplier applied to XP earned in a channel or by
+// members with a role. A multiplier of 0 stops XP from being earned.
+type XPRule struct {
+	GuildID    discord.GuildID   `db:"guildid"`
+	TargetID   discord.Snowflake `db:"targetid"`
+	TargetType XPRuleType        `db:"targettype"`
+	Multiplier float64           `db:"multiplier"`
+}
+
+const (
+	createXPRulesTableQuery = `
+		CREATE TABLE IF NOT EXISTS LevelXPRules(
+			guildID    INT8 NOT NULL,
+			targetID   INT8 NOT NULL,
+			targetType INT2 NOT NULL,
+			multiplier REAL NOT NULL,
+			PRIMARY KEY(guildID, targetID)
+		)`
+	setXPRuleQuery = `
+		INSERT INTO LevelXPRules VALUES($1, $2, $3, $4)
+		ON CONFLICT(guildID, targetID) DO UPDATE SET multiplier = $4`
+	removeXPRuleQuery = `
+		DELETE FROM LevelXPRules WHERE guildID = $1 AND targetID = $2`
+	getXPRulesQuery = `
+		SELECT * FROM LevelXPRules WHERE guildID = $1
+		ORDER BY targetType, multiplier`
+)
+
+// SetXPRule sets the XP multiplier for a channel or role in a guild.
+func (db *DB) SetXPRule(
+	guildID discord.GuildID,
+	targetID discord.Snowflake,
+	targetType XPRuleType,
+	multiplier float64) (bool, error) {
+
+	res, err := db.Exec(
+		setXPRuleQuery, guildID, targetID, targetType, multiplier,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
+
+// RemoveXPRule removes the XP multiplier for a channel or role in a guild.
+func (db *DB) RemoveXPRule(
+	guildID discord.GuildID, targetID discord.Snowflake) (bool, error) {
+
+	res, err := db.Exec(removeXPRuleQuery, guildID, targetID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	return removed > 0, err
+}
+
+// GetXPRules returns all the XP rules for a guild.
+func (db *DB) GetXPRules(guildID discord.GuildID) ([]XPRule, error) {
+	var rules []XPRule
+	err := db.Select(&rules, getXPRulesQuery, guildID)
+
+	return rules, err
+}
@@ -13,6 +13,8 @@ const (
 		INSERT INTO NotiChannelMutes VALUES($1, $2) ON CONFLICT DO NOTHING`
 	removeChannelMute = `
 		DELETE FROM NotiChannelMutes WHERE userID = $1 AND channelID = $2`
+	getChannelMutes = `
+		SELECT channelID FROM NotiChannelMutes WHERE userID = $1`
 )
 
 // MuteChannel adds a channel to a user's mute list
@@ -40,3 +42,13 @@ func (db *DB) UnmuteChannel(
 	added, err := res.RowsAffected()
 	return added > 0, err
 }
+
+// GetChannelMutes returns all channels in a user's mute list.
+func (db *DB) GetChannelMutes(
+	userID discord.UserID) ([]discord.ChannelID, error) {
+
+	var channelIDs []discord.ChannelID
+	err := db.Select(&channelIDs, getChannelMutes, userID)
+
+	return channelIDs, err
+}
@@ -17,7 +17,18 @@ func New(dbConn *sqlx.DB) *DB {
 
 func (db *DB) createTables() {
 	db.MustExec(createNotificationsTableQuery)
+	db.MustExec(addNotificationFilterColumnsQuery)
+	db.MustExec(addNotificationDeliveryColumnQuery)
 	db.MustExec(createNotiChannelMutesTableQuery)
 	db.MustExec(createNotiGuildMutesTableQuery)
 	db.MustExec(createNotiDnDTableQuery)
+	db.MustExec(createNotiDeliveryFailuresTableQuery)
+	db.MustExec(createNotiWebhooksTableQuery)
+	db.MustExec(createNotiThreadChannelsTableQuery)
+	db.MustExec(createNotiThreadsTableQuery)
+	db.MustExec(createNotiHistoryTableQuery)
+	db.MustExec(createNotiHistoryTimeIndexQuery)
+	db.MustExec(createNotiHistorySettingsTableQuery)
+	db.MustExec(createNotiServerAlertsTableQuery)
+	db.MustExec(createNotiServerAlertConfigsTableQuery)
 }
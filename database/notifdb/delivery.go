@@ -0,0 +1,114 @@
+package notifdb
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// DeliveryMethod represents where a notification is delivered to.
+type DeliveryMethod int16
+
+const (
+	// DMDelivery sends notifications to the user's DMs. This is the default.
+	DMDelivery DeliveryMethod = iota
+	// ThreadDelivery sends notifications to a private thread in the
+	// notification channel configured by the guild.
+	ThreadDelivery
+	// WebhookDelivery sends notifications to the user's personal webhook.
+	WebhookDelivery
+)
+
+// DeliveryMethods contains all delivery methods in the order they are
+// attempted when falling back from a failed delivery.
+var DeliveryMethods = []DeliveryMethod{
+	DMDelivery,
+	ThreadDelivery,
+	WebhookDelivery,
+}
+
+// String returns the string representation of a delivery method.
+func (m DeliveryMethod) String() string {
+	switch m {
+	case DMDelivery:
+		return "DM"
+	case ThreadDelivery:
+		return "Thread"
+	case WebhookDelivery:
+		return "Webhook"
+	default:
+		return "Unknown"
+	}
+}
+
+// DeliveryFailure is the number of consecutive times notifications could not
+// be delivered to a user by a delivery method.
+type DeliveryFailure struct {
+	UserID   discord.UserID `db:"userid"`
+	Method   DeliveryMethod `db:"method"`
+	Failures int32          `db:"failures"`
+}
+
+const (
+	createNotiDeliveryFailuresTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiDeliveryFailures(
+			userID   INT8 NOT NULL,
+			method   INT2 NOT NULL,
+			failures INT4 NOT NULL DEFAULT 0,
+			PRIMARY KEY(userID, method)
+		)`
+	addDeliveryFailureQuery = `
+		INSERT INTO NotiDeliveryFailures VALUES($1, $2, 1)
+		ON CONFLICT(userID, method) DO 
+		UPDATE SET failures = NotiDeliveryFailures.failures + 1
+		RETURNING failures`
+	getDeliveryFailuresQuery = `
+		SELECT * FROM NotiDeliveryFailures WHERE failures > 0`
+	getUserDeliveryFailuresQuery = `
+		SELECT * FROM NotiDeliveryFailures WHERE userID = $1 AND failures > 0`
+	resetDeliveryFailuresQuery = `
+		DELETE FROM NotiDeliveryFailures WHERE userID = $1 AND method = $2`
+	setDeliveryMethodQuery = `
+		UPDATE Notifications SET delivery = $1
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4`
+)
+
+// SetDeliveryMethod sets where a notification is delivered to. A guild ID of 0
+// refers to a global notification.
+func (db *DB) SetDeliveryMethod(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	method DeliveryMethod) (bool, error) {
+
+	return db.execUpdate(setDeliveryMethodQuery, method, keyword, userID, guildID)
+}
+
+// AddDeliveryFailure records a failed delivery attempt for a user and returns
+// the number of consecutive failures for the delivery method.
+func (db *DB) AddDeliveryFailure(
+	userID discord.UserID, method DeliveryMethod) (failures int32, err error) {
+
+	return failures, db.Get(&failures, addDeliveryFailureQuery, userID, method)
+}
+
+// GetDeliveryFailures returns all delivery methods that have failed for users
+// since they last delivered a notification.
+func (db *DB) GetDeliveryFailures() (failures []DeliveryFailure, err error) {
+	return failures, db.Select(&failures, getDeliveryFailuresQuery)
+}
+
+// GetUserDeliveryFailures returns the delivery methods that have failed for a
+// user since they last delivered a notification.
+func (db *DB) GetUserDeliveryFailures(
+	userID discord.UserID) (failures []DeliveryFailure, err error) {
+
+	return failures, db.Select(&failures, getUserDeliveryFailuresQuery, userID)
+}
+
+// ResetDeliveryFailures clears the consecutive delivery failures for a user's
+// delivery method.
+func (db *DB) ResetDeliveryFailures(
+	userID discord.UserID, method DeliveryMethod) error {
+
+	_, err := db.Exec(resetDeliveryFailuresQuery, userID, method)
+	return err
+}
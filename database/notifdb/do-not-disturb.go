@@ -12,6 +12,8 @@ const (
 		INSERT INTO NotiDnD VALUES($1) ON CONFLICT DO NOTHING`
 	removeDnD = `
 		DELETE FROM NotiDnD WHERE userID = $1`
+	getDnD = `
+		SELECT EXISTS(SELECT 1 FROM NotiDnD WHERE userID = $1)`
 )
 
 // ToggleDnD toggles whether a user has do not disturb turned on or off.
@@ -31,3 +33,20 @@ func (db *DB) ToggleDnD(userID discord.UserID) (bool, error) {
 
 	return added > 0, err
 }
+
+// SetDnD sets whether a user has do not disturb turned on or off.
+func (db *DB) SetDnD(userID discord.UserID, dndOn bool) error {
+	var err error
+	if dndOn {
+		_, err = db.Exec(addDnD, userID)
+	} else {
+		_, err = db.Exec(removeDnD, userID)
+	}
+
+	return err
+}
+
+// HasDnD returns whether a user has do not disturb turned on.
+func (db *DB) HasDnD(userID discord.UserID) (dndOn bool, err error) {
+	return dndOn, db.Get(&dndOn, getDnD, userID)
+}
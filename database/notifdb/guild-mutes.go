@@ -13,6 +13,8 @@ const (
 		INSERT INTO NotiGuildMutes VALUES($1, $2) ON CONFLICT DO NOTHING`
 	removeGuildMute = `
 		DELETE FROM NotiGuildMutes WHERE userID = $1 AND channelID = $2`
+	getGuildMutes = `
+		SELECT guildID FROM NotiGuildMutes WHERE userID = $1`
 )
 
 // MuteGuild adds a guild to a user's mute list
@@ -40,3 +42,11 @@ func (db *DB) UnmuteGuild(
 	added, err := res.RowsAffected()
 	return added > 0, err
 }
+
+// GetGuildMutes returns all guilds in a user's mute list.
+func (db *DB) GetGuildMutes(userID discord.UserID) ([]discord.GuildID, error) {
+	var guildIDs []discord.GuildID
+	err := db.Select(&guildIDs, getGuildMutes, userID)
+
+	return guildIDs, err
+}
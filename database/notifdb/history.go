@@ -0,0 +1,205 @@
+package notifdb
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/lib/pq"
+)
+
+// DefaultHistoryRetentionDays is the number of days notification matches are
+// kept for users that have not set their own retention period.
+const DefaultHistoryRetentionDays = 30
+
+// HistoryEntry represents a keyword match that a notification was sent for.
+type HistoryEntry struct {
+	UserID    discord.UserID    `db:"userid"`
+	Keyword   string            `db:"keyword"`
+	GuildID   discord.GuildID   `db:"guildid"`
+	ChannelID discord.ChannelID `db:"channelid"`
+	MessageID discord.MessageID `db:"messageid"`
+	Time      time.Time         `db:"time"`
+}
+
+// KeywordCount represents the number of matches recorded for a keyword.
+type KeywordCount struct {
+	Keyword string `db:"keyword"`
+	Count   int64  `db:"count"`
+}
+
+// ChannelCount represents the number of matches recorded in a channel.
+type ChannelCount struct {
+	GuildID   discord.GuildID   `db:"guildid"`
+	ChannelID discord.ChannelID `db:"channelid"`
+	Count     int64             `db:"count"`
+}
+
+const (
+	createNotiHistoryTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiHistory(
+			userID    INT8         NOT NULL,
+			keyword   VARCHAR(128) NOT NULL,
+			guildID   INT8         NOT NULL,
+			channelID INT8         NOT NULL,
+			messageID INT8         NOT NULL,
+			time      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+			PRIMARY KEY(userID, messageID, keyword)
+		)`
+	createNotiHistoryTimeIndexQuery = `
+		CREATE INDEX IF NOT EXISTS NotiHistoryUserTimeIndex
+		ON NotiHistory(userID, time DESC)`
+	createNotiHistorySettingsTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiHistorySettings(
+			userID        INT8 NOT NULL,
+			retentionDays INT4 NOT NULL,
+			PRIMARY KEY(userID)
+		)`
+	addHistoryEntriesQuery = `
+		INSERT INTO NotiHistory(userID, keyword, guildID, channelID, messageID)
+		SELECT $1, keyword, $3, $4, $5 FROM unnest($2::VARCHAR[]) AS keyword
+		WHERE COALESCE(
+			(SELECT retentionDays FROM NotiHistorySettings WHERE userID = $1),
+			$6
+		) > 0
+		ON CONFLICT DO NOTHING`
+	getHistoryQuery = `
+		SELECT * FROM NotiHistory WHERE userID = $1
+		ORDER BY time DESC`
+	getKeywordHistoryQuery = `
+		SELECT * FROM NotiHistory WHERE userID = $1 AND keyword = $2
+		ORDER BY time DESC`
+	getHistoryCountQuery = `
+		SELECT COUNT(*) FROM NotiHistory WHERE userID = $1`
+	getTopKeywordsQuery = `
+		SELECT keyword, COUNT(*) AS count FROM NotiHistory 
+		WHERE userID = $1
+		GROUP BY keyword
+		ORDER BY count DESC, keyword
+		LIMIT $2`
+	getTopChannelsQuery = `
+		SELECT guildID, channelID, COUNT(*) AS count FROM NotiHistory
+		WHERE userID = $1
+		GROUP BY guildID, channelID
+		ORDER BY count DESC
+		LIMIT $2`
+	clearHistoryQuery = `
+		DELETE FROM NotiHistory WHERE userID = $1`
+	setHistoryRetentionQuery = `
+		INSERT INTO NotiHistorySettings VALUES($1, $2)
+		ON CONFLICT(userID) DO UPDATE SET retentionDays = $2`
+	getHistoryRetentionQuery = `
+		SELECT COALESCE(
+			(SELECT retentionDays FROM NotiHistorySettings WHERE userID = $1),
+			$2
+		)`
+	pruneHistoryQuery = `
+		DELETE FROM NotiHistory WHERE time < now() - make_interval(
+			days => COALESCE(
+				(
+					SELECT retentionDays FROM NotiHistorySettings 
+					WHERE userID = NotiHistory.userID
+				), 
+				$1
+			)
+		)`
+)
+
+// AddHistoryEntries records the keywords that matched a message for a user,
+// unless the user has turned off notification history.
+func (db *DB) AddHistoryEntries(
+	userID discord.UserID, keywords []string, msg discord.Message) error {
+
+	_, err := db.Exec(
+		addHistoryEntriesQuery,
+		userID,
+		pq.StringArray(keywords),
+		msg.GuildID,
+		msg.ChannelID,
+		msg.ID,
+		DefaultHistoryRetentionDays,
+	)
+
+	return err
+}
+
+// GetHistory returns all recorded matches for a user, newest first.
+func (db *DB) GetHistory(userID discord.UserID) ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+	err := db.Select(&entries, getHistoryQuery, userID)
+
+	return entries, err
+}
+
+// GetKeywordHistory returns all recorded matches of a keyword for a user,
+// newest first.
+func (db *DB) GetKeywordHistory(
+	userID discord.UserID, keyword string) ([]HistoryEntry, error) {
+
+	var entries []HistoryEntry
+	err := db.Select(&entries, getKeywordHistoryQuery, userID, keyword)
+
+	return entries, err
+}
+
+// GetHistoryCount returns the number of recorded matches for a user.
+func (db *DB) GetHistoryCount(userID discord.UserID) (count int64, err error) {
+	return count, db.Get(&count, getHistoryCountQuery, userID)
+}
+
+// GetTopKeywords returns the keywords with the most recorded matches for a
+// user.
+func (db *DB) GetTopKeywords(
+	userID discord.UserID, limit int) ([]KeywordCount, error) {
+
+	var counts []KeywordCount
+	err := db.Select(&counts, getTopKeywordsQuery, userID, limit)
+
+	return counts, err
+}
+
+// GetTopChannels returns the channels with the most recorded matches for a
+// user.
+func (db *DB) GetTopChannels(
+	userID discord.UserID, limit int) ([]ChannelCount, error) {
+
+	var counts []ChannelCount
+	err := db.Select(&counts, getTopChannelsQuery, userID, limit)
+
+	return counts, err
+}
+
+// ClearHistory removes all recorded matches for a user.
+func (db *DB) ClearHistory(userID discord.UserID) (int64, error) {
+	res, err := db.Exec(clearHistoryQuery, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// SetHistoryRetention sets how many days a user's matches are kept for.
+// A retention of 0 days turns off notification history.
+func (db *DB) SetHistoryRetention(userID discord.UserID, days int32) error {
+	_, err := db.Exec(setHistoryRetentionQuery, userID, days)
+	return err
+}
+
+// GetHistoryRetention returns how many days a user's matches are kept for.
+func (db *DB) GetHistoryRetention(
+	userID discord.UserID) (days int32, err error) {
+
+	return days, db.Get(
+		&days, getHistoryRetentionQuery, userID, DefaultHistoryRetentionDays,
+	)
+}
+
+// PruneHistory removes all matches older than their user's retention period.
+func (db *DB) PruneHistory() (int64, error) {
+	res, err := db.Exec(pruneHistoryQuery, DefaultHistoryRetentionDays)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -1,7 +1,10 @@
 package notifdb
 
 import (
+	"time"
+
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/lib/pq"
 )
 
 // NotificationType represents the type of the notification which is used to
@@ -40,7 +43,63 @@ type Notification struct {
 	Type    NotificationType `db:"type"`
 
 	// if guildID is 0, notification is global.
-	GuildID discord.GuildID `db:"guildid"`
+	GuildID  discord.GuildID `db:"guildid"`
+	Delivery DeliveryMethod  `db:"delivery"`
+
+	Filters `db:""`
+}
+
+// Filters represents the optional filters and cooldown that restrict when a
+// notification is sent.
+type Filters struct {
+	// CooldownSeconds is the minimum number of seconds between notifications
+	// sent for the keyword in a single channel.
+	CooldownSeconds int32 `db:"cooldown"`
+	// OnlyUserIDs is a list of users whose messages are exclusively checked
+	// for the keyword, if non-empty.
+	OnlyUserIDs pq.Int64Array `db:"onlyuserids"`
+	// IgnoredUserIDs is a list of users whose messages are never checked
+	// for the keyword.
+	IgnoredUserIDs pq.Int64Array `db:"ignoreduserids"`
+	// IgnoreBots determines whether messages sent by bots and webhooks are
+	// ignored.
+	IgnoreBots bool `db:"ignorebots"`
+	// RequiredRoleID is a role the message author must have for the keyword to
+	// be checked. If 0, no role is required.
+	RequiredRoleID discord.RoleID `db:"requiredroleid"`
+}
+
+// Cooldown returns the cooldown between notifications in a single channel.
+func (f Filters) Cooldown() time.Duration {
+	return time.Duration(f.CooldownSeconds) * time.Second
+}
+
+// OnlyUsers returns the user IDs that notifications are exclusively sent for.
+func (f Filters) OnlyUsers() []discord.UserID {
+	return int64sToUserIDs(f.OnlyUserIDs)
+}
+
+// IgnoredUsers returns the user IDs that notifications are never sent for.
+func (f Filters) IgnoredUsers() []discord.UserID {
+	return int64sToUserIDs(f.IgnoredUserIDs)
+}
+
+// IsFiltered returns whether any filters or cooldowns are set.
+func (f Filters) IsFiltered() bool {
+	return f.CooldownSeconds > 0 ||
+		len(f.OnlyUserIDs) > 0 ||
+		len(f.IgnoredUserIDs) > 0 ||
+		!f.IgnoreBots ||
+		f.RequiredRoleID.IsValid()
+}
+
+func int64sToUserIDs(ids []int64) []discord.UserID {
+	userIDs := make([]discord.UserID, len(ids))
+	for i, id := range ids {
+		userIDs[i] = discord.UserID(id)
+	}
+
+	return userIDs
 }
 
 const (
@@ -50,11 +109,37 @@ const (
 			userID  INT8         NOT NULL,
 			type    INT2         NOT NULL DEFAULT 0,
 			guildID INT8         NOT NULL DEFAULT 0,
+
+			cooldown       INT4    NOT NULL DEFAULT 0,
+			onlyUserIDs    INT8[]  NOT NULL DEFAULT '{}',
+			ignoredUserIDs INT8[]  NOT NULL DEFAULT '{}',
+			ignoreBots     BOOLEAN NOT NULL DEFAULT TRUE,
+			requiredRoleID INT8    NOT NULL DEFAULT 0,
+
+			delivery       INT2    NOT NULL DEFAULT 0,
+
 			PRIMARY KEY(keyword, userID, guildID)
 		)`
+	addNotificationFilterColumnsQuery = `
+		ALTER TABLE Notifications
+			ADD COLUMN IF NOT EXISTS cooldown       INT4    NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS onlyUserIDs    INT8[]  NOT NULL DEFAULT '{}',
+			ADD COLUMN IF NOT EXISTS ignoredUserIDs INT8[]  NOT NULL DEFAULT '{}',
+			ADD COLUMN IF NOT EXISTS ignoreBots     BOOLEAN NOT NULL DEFAULT TRUE,
+			ADD COLUMN IF NOT EXISTS requiredRoleID INT8    NOT NULL DEFAULT 0`
+	addNotificationDeliveryColumnQuery = `
+		ALTER TABLE Notifications
+			ADD COLUMN IF NOT EXISTS delivery INT2 NOT NULL DEFAULT 0`
 
 	addNotificationQuery = `
 		INSERT INTO Notifications VALUES($1, $2, $3, $4) ON CONFLICT DO NOTHING`
+	importNotificationQuery = `
+		INSERT INTO Notifications(
+			keyword, userID, type, guildID, cooldown, onlyUserIDs,
+			ignoredUserIDs, ignoreBots, requiredRoleID, delivery
+		)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT DO NOTHING`
 	addGlobalNotificationQuery = `
 		INSERT INTO Notifications VALUES($1, $2, $3, 0) ON CONFLICT DO NOTHING`
 	removeNotificationQuery = `
@@ -67,6 +152,31 @@ const (
 		DELETE FROM Notifications WHERE userID = $1 AND guildID = $2`
 	clearGlobalUserNotifications = `
 		DELETE FROM Notifications WHERE userID = $1 AND guildID = 0`
+	setCooldownQuery = `
+		UPDATE Notifications SET cooldown = $1
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4`
+	setIgnoreBotsQuery = `
+		UPDATE Notifications SET ignoreBots = $1
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4`
+	setRequiredRoleQuery = `
+		UPDATE Notifications SET requiredRoleID = $1
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4`
+	addOnlyUserQuery = `
+		UPDATE Notifications SET 
+			onlyUserIDs = array_append(array_remove(onlyUserIDs, $1), $1),
+			ignoredUserIDs = array_remove(ignoredUserIDs, $1)
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4`
+	addIgnoredUserQuery = `
+		UPDATE Notifications SET 
+			ignoredUserIDs = array_append(array_remove(ignoredUserIDs, $1), $1),
+			onlyUserIDs = array_remove(onlyUserIDs, $1)
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4`
+	removeFilteredUserQuery = `
+		UPDATE Notifications SET 
+			onlyUserIDs = array_remove(onlyUserIDs, $1),
+			ignoredUserIDs = array_remove(ignoredUserIDs, $1)
+		WHERE keyword = $2 AND userID = $3 AND guildID = $4
+			AND $1 = ANY(onlyUserIDs || ignoredUserIDs)`
 
 	// getAllCheckingNotificationsQuery is an SQL query that fetches all stored
 	// notificatons that satisfy the following:
@@ -76,6 +186,10 @@ const (
 	//   global
 	// - the guild ID is not muted by the user the notification belongs to
 	// - the channel ID is not muted by the user the notification belongs to
+	// - the author is in the notification's only users list, if it has one
+	// - the author is not in the notification's ignored users list
+	// - the author is not a bot, if the notification ignores bots
+	// - the author has the notification's required role, if it has one
 	getAllCheckingNotificationsQuery = `
 		SELECT * FROM Notifications 
 		WHERE (
@@ -94,6 +208,14 @@ const (
 				SELECT channelID FROM NotiChannelMutes
 				WHERE userID = Notifications.userID
 			)
+				AND
+			(cardinality(onlyUserIDs) = 0 OR $1 = ANY(onlyUserIDs))
+				AND
+			$1 != ALL(ignoredUserIDs)
+				AND
+			NOT (ignoreBots AND $4)
+				AND
+			(requiredRoleID = 0 OR requiredRoleID = ANY($5::INT8[]))
 		)`
 
 	getUserNotificationsQuery = `
@@ -102,6 +224,9 @@ const (
 		SELECT * FROM Notifications WHERE userID = $1 AND guildID = 0`
 	getUserGuildNotificationsQuery = `
 		SELECT * FROM Notifications WHERE userID = $1 AND guildID = $2`
+	getNotificationQuery = `
+		SELECT * FROM Notifications 
+		WHERE keyword = $1 AND userID = $2 AND guildID = $3`
 	getUserGuildAndGlobalNotificationsQuery = `
 		SELECT * FROM Notifications 
 		WHERE userID = $1 AND (guildID = $2 OR guildID = 0)`
@@ -138,6 +263,23 @@ func (db *DB) AddGlobal(
 	return added > 0, err
 }
 
+// Import adds a notification along with its filters and delivery method.
+func (db *DB) Import(noti Notification) (bool, error) {
+	return db.execUpdate(
+		importNotificationQuery,
+		noti.Keyword,
+		noti.UserID,
+		noti.Type,
+		noti.GuildID,
+		noti.CooldownSeconds,
+		noti.OnlyUserIDs,
+		noti.IgnoredUserIDs,
+		noti.IgnoreBots,
+		noti.RequiredRoleID,
+		noti.Delivery,
+	)
+}
+
 // Remove removes a guild notifiaction for a keyword being sent to userID.
 func (db *DB) Remove(
 	keyword string,
@@ -191,23 +333,124 @@ func (db *DB) ClearGlobal(userID discord.UserID) (int64, error) {
 	return cleared, err
 }
 
-// GetAll returns all notifications that can potentially be checked
-// for keywords.
+// SetCooldown sets the per-channel cooldown for a notification. A guild ID
+// of 0 refers to a global notification.
+func (db *DB) SetCooldown(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	cooldown time.Duration) (bool, error) {
+
+	seconds := int32(cooldown / time.Second)
+	return db.execUpdate(setCooldownQuery, seconds, keyword, userID, guildID)
+}
+
+// SetIgnoreBots sets whether a notification ignores messages sent by bots and
+// webhooks. A guild ID of 0 refers to a global notification.
+func (db *DB) SetIgnoreBots(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	ignore bool) (bool, error) {
+
+	return db.execUpdate(setIgnoreBotsQuery, ignore, keyword, userID, guildID)
+}
+
+// SetRequiredRole sets the role a message author must have for a
+// notification to be sent. A role ID of 0 removes the requirement.
+func (db *DB) SetRequiredRole(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	roleID discord.RoleID) (bool, error) {
+
+	return db.execUpdate(setRequiredRoleQuery, roleID, keyword, userID, guildID)
+}
+
+// AddOnlyUser adds a user to the list of users a notification is exclusively
+// sent for, removing them from the ignored users list if present.
+func (db *DB) AddOnlyUser(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	targetID discord.UserID) (bool, error) {
+
+	return db.execUpdate(addOnlyUserQuery, targetID, keyword, userID, guildID)
+}
+
+// AddIgnoredUser adds a user to the list of users a notification is never
+// sent for, removing them from the only users list if present.
+func (db *DB) AddIgnoredUser(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	targetID discord.UserID) (bool, error) {
+
+	return db.execUpdate(
+		addIgnoredUserQuery, targetID, keyword, userID, guildID,
+	)
+}
+
+// RemoveFilteredUser removes a user from both the only users and ignored
+// users lists of a notification.
+func (db *DB) RemoveFilteredUser(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	targetID discord.UserID) (bool, error) {
+
+	return db.execUpdate(
+		removeFilteredUserQuery, targetID, keyword, userID, guildID,
+	)
+}
+
+func (db *DB) execUpdate(query string, args ...any) (bool, error) {
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	return updated > 0, err
+}
+
+// GetAllChecking returns all notifications that can potentially be checked
+// for keywords in a message sent by the author.
 func (db *DB) GetAllChecking(
 	authorID discord.UserID,
 	guildID discord.GuildID,
-	channelID discord.ChannelID) ([]Notification, error) {
+	channelID discord.ChannelID,
+	authorIsBot bool,
+	authorRoleIDs []discord.RoleID) ([]Notification, error) {
+
+	roleIDs := make(pq.Int64Array, len(authorRoleIDs))
+	for i, id := range authorRoleIDs {
+		roleIDs[i] = int64(id)
+	}
 
 	var notifications []Notification
 	err := db.Select(
 		&notifications,
 		getAllCheckingNotificationsQuery,
-		authorID, guildID, channelID,
+		authorID, guildID, channelID, authorIsBot, roleIDs,
 	)
 
 	return notifications, err
 }
 
+// GetByKeyword returns a user's notification for a keyword. A guild ID of 0
+// refers to a global notification.
+func (db *DB) GetByKeyword(
+	keyword string,
+	userID discord.UserID,
+	guildID discord.GuildID) (*Notification, error) {
+
+	var notification Notification
+	err := db.Get(&notification, getNotificationQuery, keyword, userID, guildID)
+
+	return &notification, err
+}
+
 // GetByUser returns all notifications registered to a user.
 func (db *DB) GetByUser(userID discord.UserID) ([]Notification, error) {
 	var notifications []Notification
@@ -0,0 +1,117 @@
+package notifdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+// ServerAlert represents a guild keyword alert that is posted to the guild's
+// alert channel for moderators.
+type ServerAlert struct {
+	GuildID discord.GuildID  `db:"guildid"`
+	Keyword string           `db:"keyword"`
+	Type    NotificationType `db:"type"`
+}
+
+// ServerAlertConfig represents the guild settings for keyword alerts.
+type ServerAlertConfig struct {
+	GuildID       discord.GuildID   `db:"guildid"`
+	ChannelID     discord.ChannelID `db:"channelid"`
+	ManagerRoleID discord.RoleID    `db:"managerroleid"`
+}
+
+const (
+	createNotiServerAlertsTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiServerAlerts(
+			guildID INT8         NOT NULL,
+			keyword VARCHAR(128) NOT NULL,
+			type    INT2         NOT NULL DEFAULT 0,
+			PRIMARY KEY(guildID, keyword)
+		)`
+	createNotiServerAlertConfigsTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiServerAlertConfigs(
+			guildID       INT8 NOT NULL,
+			channelID     INT8 NOT NULL DEFAULT 0,
+			managerRoleID INT8 NOT NULL DEFAULT 0,
+			PRIMARY KEY(guildID)
+		)`
+	addServerAlertQuery = `
+		INSERT INTO NotiServerAlerts VALUES($1, $2, $3) ON CONFLICT DO NOTHING`
+	removeServerAlertQuery = `
+		DELETE FROM NotiServerAlerts WHERE guildID = $1 AND keyword = $2`
+	getServerAlertsQuery = `
+		SELECT * FROM NotiServerAlerts WHERE guildID = $1 ORDER BY keyword`
+	getCheckingServerAlertsQuery = `
+		SELECT NotiServerAlerts.* FROM NotiServerAlerts 
+		INNER JOIN NotiServerAlertConfigs USING(guildID)
+		WHERE guildID = $1 AND channelID != 0`
+	setServerAlertChannelQuery = `
+		INSERT INTO NotiServerAlertConfigs(guildID, channelID) VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET channelID = $2`
+	setServerAlertManagerRoleQuery = `
+		INSERT INTO NotiServerAlertConfigs(guildID, managerRoleID) 
+		VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET managerRoleID = $2`
+	getServerAlertConfigQuery = `
+		SELECT * FROM NotiServerAlertConfigs WHERE guildID = $1`
+)
+
+// AddServerAlert adds a keyword alert to a guild.
+func (db *DB) AddServerAlert(
+	guildID discord.GuildID,
+	keyword string,
+	alertType NotificationType) (bool, error) {
+
+	return db.execUpdate(addServerAlertQuery, guildID, keyword, alertType)
+}
+
+// RemoveServerAlert removes a keyword alert from a guild.
+func (db *DB) RemoveServerAlert(
+	guildID discord.GuildID, keyword string) (bool, error) {
+
+	return db.execUpdate(removeServerAlertQuery, guildID, keyword)
+}
+
+// GetServerAlerts returns all keyword alerts set up in a guild.
+func (db *DB) GetServerAlerts(guildID discord.GuildID) ([]ServerAlert, error) {
+	var alerts []ServerAlert
+	err := db.Select(&alerts, getServerAlertsQuery, guildID)
+
+	return alerts, err
+}
+
+// GetCheckingServerAlerts returns all keyword alerts that should be checked
+// for a guild, which excludes guilds without an alert channel set up.
+func (db *DB) GetCheckingServerAlerts(
+	guildID discord.GuildID) ([]ServerAlert, error) {
+
+	var alerts []ServerAlert
+	err := db.Select(&alerts, getCheckingServerAlertsQuery, guildID)
+
+	return alerts, err
+}
+
+// SetServerAlertChannel sets the channel that keyword alerts are posted to in
+// a guild. A channel ID of 0 turns off keyword alerts.
+func (db *DB) SetServerAlertChannel(
+	guildID discord.GuildID, channelID discord.ChannelID) error {
+
+	_, err := db.Exec(setServerAlertChannelQuery, guildID, channelID)
+	return err
+}
+
+// SetServerAlertManagerRole sets the role that can manage keyword alerts in a
+// guild. A role ID of 0 removes the manager role.
+func (db *DB) SetServerAlertManagerRole(
+	guildID discord.GuildID, roleID discord.RoleID) error {
+
+	_, err := db.Exec(setServerAlertManagerRoleQuery, guildID, roleID)
+	return err
+}
+
+// GetServerAlertConfig returns the keyword alert settings for a guild.
+func (db *DB) GetServerAlertConfig(
+	guildID discord.GuildID) (*ServerAlertConfig, error) {
+
+	var config ServerAlertConfig
+	err := db.Get(&config, getServerAlertConfigQuery, guildID)
+
+	return &config, err
+}
@@ -0,0 +1,121 @@
+package notifdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+const (
+	createNotiThreadChannelsTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiThreadChannels(
+			guildID   INT8 NOT NULL,
+			channelID INT8 NOT NULL,
+			PRIMARY KEY(guildID)
+		)`
+	createNotiThreadsTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiThreads(
+			userID   INT8 NOT NULL,
+			guildID  INT8 NOT NULL,
+			threadID INT8 NOT NULL,
+			PRIMARY KEY(userID, guildID)
+		)`
+	setThreadChannelQuery = `
+		INSERT INTO NotiThreadChannels VALUES($1, $2)
+		ON CONFLICT(guildID) DO UPDATE SET channelID = $2`
+	removeThreadChannelQuery = `
+		DELETE FROM NotiThreadChannels WHERE guildID = $1`
+	getThreadChannelQuery = `
+		SELECT channelID FROM NotiThreadChannels WHERE guildID = $1`
+	clearGuildThreadsQuery = `
+		DELETE FROM NotiThreads WHERE guildID = $1`
+	setThreadQuery = `
+		INSERT INTO NotiThreads VALUES($1, $2, $3)
+		ON CONFLICT(userID, guildID) DO UPDATE SET threadID = $3`
+	removeThreadQuery = `
+		DELETE FROM NotiThreads WHERE userID = $1 AND guildID = $2`
+	getThreadQuery = `
+		SELECT threadID FROM NotiThreads WHERE userID = $1 AND guildID = $2`
+)
+
+// SetThreadChannel sets the channel in a guild that private notification
+// threads are created in, and forgets any threads in the previous channel.
+func (db *DB) SetThreadChannel(
+	guildID discord.GuildID, channelID discord.ChannelID) error {
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(setThreadChannelQuery, guildID, channelID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(clearGuildThreadsQuery, guildID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveThreadChannel disables private notification threads in a guild.
+func (db *DB) RemoveThreadChannel(guildID discord.GuildID) (bool, error) {
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(removeThreadChannelQuery, guildID)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(clearGuildThreadsQuery, guildID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, tx.Commit()
+}
+
+// GetThreadChannel returns the channel in a guild that private notification
+// threads are created in.
+func (db *DB) GetThreadChannel(
+	guildID discord.GuildID) (channelID discord.ChannelID, err error) {
+
+	return channelID, db.Get(&channelID, getThreadChannelQuery, guildID)
+}
+
+// SetThread sets the private notification thread for a user in a guild.
+func (db *DB) SetThread(
+	userID discord.UserID,
+	guildID discord.GuildID,
+	threadID discord.ChannelID) error {
+
+	_, err := db.Exec(setThreadQuery, userID, guildID, threadID)
+	return err
+}
+
+// RemoveThread removes the private notification thread for a user in a guild.
+func (db *DB) RemoveThread(
+	userID discord.UserID, guildID discord.GuildID) error {
+
+	_, err := db.Exec(removeThreadQuery, userID, guildID)
+	return err
+}
+
+// GetThread returns the private notification thread for a user in a guild.
+func (db *DB) GetThread(
+	userID discord.UserID,
+	guildID discord.GuildID) (threadID discord.ChannelID, err error) {
+
+	return threadID, db.Get(&threadID, getThreadQuery, userID, guildID)
+}
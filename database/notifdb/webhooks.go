@@ -0,0 +1,41 @@
+package notifdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+const (
+	createNotiWebhooksTableQuery = `
+		CREATE TABLE IF NOT EXISTS NotiWebhooks(
+			userID INT8         NOT NULL,
+			url    VARCHAR(256) NOT NULL,
+			PRIMARY KEY(userID)
+		)`
+	setWebhookQuery = `
+		INSERT INTO NotiWebhooks VALUES($1, $2)
+		ON CONFLICT(userID) DO UPDATE SET url = $2`
+	removeWebhookQuery = `
+		DELETE FROM NotiWebhooks WHERE userID = $1`
+	getWebhookQuery = `
+		SELECT url FROM NotiWebhooks WHERE userID = $1`
+)
+
+// SetWebhook sets a user's personal webhook URL to deliver notifications to.
+func (db *DB) SetWebhook(userID discord.UserID, url string) error {
+	_, err := db.Exec(setWebhookQuery, userID, url)
+	return err
+}
+
+// RemoveWebhook removes a user's personal webhook URL.
+func (db *DB) RemoveWebhook(userID discord.UserID) (bool, error) {
+	res, err := db.Exec(removeWebhookQuery, userID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	return removed > 0, err
+}
+
+// GetWebhook returns a user's personal webhook URL.
+func (db *DB) GetWebhook(userID discord.UserID) (url string, err error) {
+	return url, db.Get(&url, getWebhookQuery, userID)
+}
@@ -17,4 +17,9 @@ func New(dbConn *sqlx.DB) *DB {
 
 func (db *DB) createTables() {
 	db.MustExec(createRemindersTableQuery)
+	db.MustExec(addReminderScheduleColumnsQuery)
+	db.MustExec(addReminderTargetColumnsQuery)
+	db.MustExec(addReminderDeliveryColumnsQuery)
+	db.MustExec(addReminderMessageLinkColumnQuery)
+	db.MustExec(createSubscribersTableQuery)
 }
@@ -12,6 +12,67 @@ type Reminder struct {
 	Time    time.Time      `db:"time"`
 	Content string         `db:"content"`
 	Created time.Time      `db:"created"`
+	// MessageLink is a link to the message the reminder is about, if any.
+	MessageLink string `db:"messagelink"`
+
+	Schedule `db:""`
+	Target   `db:""`
+	Delivery `db:""`
+}
+
+// Delivery represents the state of attempts to send a reminder.
+type Delivery struct {
+	// OriginChannelID is the guild channel the reminder was created in, used
+	// as a fallback when the reminder cannot be sent to the user's DMs.
+	OriginChannelID discord.ChannelID `db:"originchannelid"`
+	// Attempts is the number of times sending the reminder has failed.
+	Attempts int16 `db:"attempts"`
+	// NextAttempt is the time sending the reminder is next retried, if it
+	// has failed to send.
+	NextAttempt *time.Time `db:"nextattempt"`
+	// Failed is whether the reminder has failed to send too many times and
+	// will no longer be retried.
+	Failed bool `db:"failed"`
+}
+
+// DueTime returns the time the reminder should next be sent.
+func (r Reminder) DueTime() time.Time {
+	if r.NextAttempt != nil {
+		return *r.NextAttempt
+	}
+
+	return r.Time
+}
+
+// Target represents the guild channel a reminder is posted to. Reminders
+// without a target channel are sent to the user's DMs.
+type Target struct {
+	GuildID   discord.GuildID   `db:"guildid"`
+	ChannelID discord.ChannelID `db:"channelid"`
+	// RoleID is the role mentioned when the reminder is posted, if set.
+	RoleID discord.RoleID `db:"roleid"`
+}
+
+// IsChannel returns whether the reminder is posted to a guild channel.
+func (t Target) IsChannel() bool {
+	return t.ChannelID.IsValid()
+}
+
+// Schedule represents how a reminder repeats after it is sent.
+type Schedule struct {
+	// Expression is the user-provided schedule expression the next reminder
+	// time is calculated from. If empty, the reminder does not repeat.
+	Expression string `db:"schedule"`
+	// EndTime is the time after which the reminder stops repeating, if set.
+	EndTime *time.Time `db:"endtime"`
+	// Remaining is the number of times the reminder is still to be sent,
+	// including the pending reminder. If 0, the reminder repeats indefinitely.
+	Remaining int32 `db:"remaining"`
+}
+
+// IsRecurring returns whether the reminder repeats after it is sent.
+func (s Schedule) IsRecurring() bool {
+	return s.Expression != ""
 }
 
 const (
@@ -22,17 +83,96 @@ const (
 			time    TIMESTAMP     NOT NULL,
 			content VARCHAR(2048) NOT NULL,
 			created TIMESTAMPTZ   NOT NULL DEFAULT now(),
+
+			schedule  VARCHAR(128) NOT NULL DEFAULT '',
+			endTime   TIMESTAMP,
+			remaining INT4         NOT NULL DEFAULT 0,
+
+			guildID   INT8         NOT NULL DEFAULT 0,
+			channelID INT8         NOT NULL DEFAULT 0,
+			roleID    INT8         NOT NULL DEFAULT 0,
+
+			originChannelID INT8      NOT NULL DEFAULT 0,
+			attempts        INT2      NOT NULL DEFAULT 0,
+			nextAttempt     TIMESTAMP,
+			failed          BOOLEAN   NOT NULL DEFAULT FALSE,
+
+			messageLink VARCHAR(256) NOT NULL DEFAULT '',
+
 			PRIMARY KEY(id)
 		)`
+	addReminderScheduleColumnsQuery = `
+		ALTER TABLE Reminders
+			ADD COLUMN IF NOT EXISTS schedule  VARCHAR(128) NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS endTime   TIMESTAMP,
+			ADD COLUMN IF NOT EXISTS remaining INT4         NOT NULL DEFAULT 0`
+	addReminderTargetColumnsQuery = `
+		ALTER TABLE Reminders
+			ADD COLUMN IF NOT EXISTS guildID   INT8 NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS channelID INT8 NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS roleID    INT8 NOT NULL DEFAULT 0`
+	addReminderDeliveryColumnsQuery = `
+		ALTER TABLE Reminders
+			ADD COLUMN IF NOT EXISTS originChannelID INT8      NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS attempts        INT2      NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS nextAttempt     TIMESTAMP,
+			ADD COLUMN IF NOT EXISTS failed          BOOLEAN   NOT NULL DEFAULT FALSE`
+	addReminderMessageLinkColumnQuery = `
+		ALTER TABLE Reminders
+			ADD COLUMN IF NOT EXISTS messageLink VARCHAR(256) NOT NULL DEFAULT ''`
 	addReminderQuery = `
 		INSERT INTO Reminders (userID, time, content) 
 		VALUES($1, $2, $3)
 		RETURNING id`
+	addRecurringReminderQuery = `
+		INSERT INTO Reminders (
+			userID, time, content, schedule, endTime, remaining,
+			originChannelID, messageLink
+		) 
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+	addChannelReminderQuery = `
+		INSERT INTO Reminders (
+			userID, time, content, schedule, endTime, remaining,
+			guildID, channelID, roleID
+		) 
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+	addSnoozedReminderQuery = `
+		INSERT INTO Reminders (userID, time, content, messageLink, created) 
+		VALUES($1, $2, $3, $4, $5)
+		RETURNING id`
+	setScheduleQuery = `
+		UPDATE Reminders SET schedule = $1, endTime = $2, remaining = $3
+		WHERE userID = $4 AND id = $5`
+	rescheduleQuery = `
+		UPDATE Reminders 
+		SET time = $1, remaining = $2, attempts = 0, nextAttempt = NULL
+		WHERE id = $3`
+	recordFailedAttemptQuery = `
+		UPDATE Reminders SET attempts = attempts + 1, nextAttempt = $1
+		WHERE id = $2`
+	markFailedQuery = `
+		UPDATE Reminders 
+		SET attempts = attempts + 1, nextAttempt = NULL, failed = TRUE
+		WHERE id = $1`
+	setTimeQuery = `
+		UPDATE Reminders 
+		SET time = $1, attempts = 0, nextAttempt = NULL, failed = FALSE
+		WHERE userID = $2 AND id = $3`
+	setContentQuery = `
+		UPDATE Reminders SET content = $1 WHERE userID = $2 AND id = $3`
 	deleteReminderQuery = `
 		DELETE FROM Reminders WHERE userID = $1 AND id = $2`
-	clearRemindersQuery   = `DELETE FROM Reminders WHERE userID = $1`
-	getAllRemindersByUser = `SELECT * FROM Reminders WHERE userID = $1`
-	getOverdueReminders   = `SELECT * FROM Reminders WHERE time <= now()`
+	deleteGuildReminderQuery = `
+		DELETE FROM Reminders WHERE guildID = $1 AND id = $2`
+	clearRemindersQuery    = `DELETE FROM Reminders WHERE userID = $1`
+	getReminderQuery       = `SELECT * FROM Reminders WHERE id = $1`
+	getAllRemindersByUser  = `SELECT * FROM Reminders WHERE userID = $1`
+	getAllRemindersByGuild = `SELECT * FROM Reminders WHERE guildID = $1`
+	getRemindersDueBefore  = `
+		SELECT * FROM Reminders 
+		WHERE COALESCE(nextAttempt, time) <= $1 AND NOT failed`
 )
 
 // Add adds a reminder for a user.
@@ -40,7 +180,7 @@ func (db *DB) Add(
 	userID discord.UserID, time time.Time, content string) (int32, error) {
 
 	var id int32
-	err := db.Get(&id, addReminderQuery, userID, time, content)
+	err := db.Get(&id, addReminderQuery, userID, time.UTC(), content)
 	if err != nil {
 		return 0, err
 	}
@@ -48,6 +188,163 @@ func (db *DB) Add(
 	return id, nil
 }
 
+// AddRecurring adds a reminder for a user that repeats on a schedule. The
+// origin channel is the guild channel the reminder is posted to if it cannot
+// be sent to the user's DMs, and may be 0. The message link may be empty if
+// the reminder is not about a message.
+func (db *DB) AddRecurring(
+	userID discord.UserID,
+	originChannelID discord.ChannelID,
+	time time.Time,
+	content string,
+	messageLink string,
+	schedule Schedule) (int32, error) {
+
+	var id int32
+	err := db.Get(
+		&id,
+		addRecurringReminderQuery,
+		userID,
+		time.UTC(),
+		content,
+		schedule.Expression,
+		utcTime(schedule.EndTime),
+		schedule.Remaining,
+		originChannelID,
+		messageLink,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// AddToChannel adds a reminder created by a user that is posted to a guild
+// channel, optionally repeating on a schedule.
+func (db *DB) AddToChannel(
+	userID discord.UserID,
+	target Target,
+	time time.Time,
+	content string,
+	schedule Schedule) (int32, error) {
+
+	var id int32
+	err := db.Get(
+		&id,
+		addChannelReminderQuery,
+		userID,
+		time.UTC(),
+		content,
+		schedule.Expression,
+		utcTime(schedule.EndTime),
+		schedule.Remaining,
+		target.GuildID,
+		target.ChannelID,
+		target.RoleID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// AddSnoozed adds a reminder for a user that was snoozed after being sent,
+// preserving the time the reminder was originally created.
+func (db *DB) AddSnoozed(
+	userID discord.UserID,
+	time time.Time,
+	content string,
+	messageLink string,
+	created time.Time) (int32, error) {
+
+	var id int32
+	err := db.Get(
+		&id,
+		addSnoozedReminderQuery,
+		userID,
+		time.UTC(),
+		content,
+		messageLink,
+		created,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// SetSchedule sets how a user's reminder repeats. An empty schedule
+// expression stops the reminder from repeating.
+func (db *DB) SetSchedule(
+	userID discord.UserID, id int32, schedule Schedule) (bool, error) {
+
+	res, err := db.Exec(
+		setScheduleQuery,
+		schedule.Expression,
+		utcTime(schedule.EndTime),
+		schedule.Remaining,
+		userID,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	return updated > 0, err
+}
+
+// Reschedule sets the next time a recurring reminder is sent, along with the
+// number of times it is still to be sent, and resets its failed attempts.
+func (db *DB) Reschedule(id int32, time time.Time, remaining int32) error {
+	_, err := db.Exec(rescheduleQuery, time.UTC(), remaining, id)
+	return err
+}
+
+// SetTime sets the time a user's reminder is sent, resetting any failed
+// attempts to send it.
+func (db *DB) SetTime(
+	userID discord.UserID, id int32, time time.Time) (bool, error) {
+
+	res, err := db.Exec(setTimeQuery, time.UTC(), userID, id)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	return updated > 0, err
+}
+
+// SetContent sets the content of a user's reminder.
+func (db *DB) SetContent(
+	userID discord.UserID, id int32, content string) (bool, error) {
+
+	res, err := db.Exec(setContentQuery, content, userID, id)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	return updated > 0, err
+}
+
+// RecordFailedAttempt increments the number of failed attempts to send a
+// reminder and sets the time sending it is next retried.
+func (db *DB) RecordFailedAttempt(id int32, next time.Time) error {
+	_, err := db.Exec(recordFailedAttemptQuery, next.UTC(), id)
+	return err
+}
+
+// MarkFailed increments the number of failed attempts to send a reminder and
+// stops it from being retried.
+func (db *DB) MarkFailed(id int32) error {
+	_, err := db.Exec(markFailedQuery, id)
+	return err
+}
+
 // DeleteForUser deletes a reminder for a user.
 func (db *DB) DeleteForUser(userID discord.UserID, id int32) (bool, error) {
 	res, err := db.Exec(deleteReminderQuery, userID, id)
@@ -59,6 +356,17 @@ func (db *DB) DeleteForUser(userID discord.UserID, id int32) (bool, error) {
 	return deleted > 0, err
 }
 
+// DeleteForGuild deletes a reminder posted to a channel in a guild.
+func (db *DB) DeleteForGuild(guildID discord.GuildID, id int32) (bool, error) {
+	res, err := db.Exec(deleteGuildReminderQuery, guildID, id)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := res.RowsAffected()
+	return deleted > 0, err
+}
+
 // ClearByUser deletes all reminders for a user.
 func (db *DB) ClearByUser(userID discord.UserID) (int64, error) {
 	res, err := db.Exec(clearRemindersQuery, userID)
@@ -70,6 +378,14 @@ func (db *DB) ClearByUser(userID discord.UserID) (int64, error) {
 	return deleted, err
 }
 
+// GetReminder returns the reminder with the provided ID.
+func (db *DB) GetReminder(id int32) (*Reminder, error) {
+	var reminder Reminder
+	err := db.Get(&reminder, getReminderQuery, id)
+
+	return &reminder, err
+}
+
 // GetAllByUser returns all the reminders for a user.
 func (db *DB) GetAllByUser(userID discord.UserID) ([]Reminder, error) {
 	var reminders []Reminder
@@ -78,10 +394,30 @@ func (db *DB) GetAllByUser(userID discord.UserID) ([]Reminder, error) {
 	return reminders, err
 }
 
-// GetOverdueReminders returns all reminders that are ready to be sent to users.
-func (db *DB) GetOverdueReminders() ([]Reminder, error) {
+// GetAllByGuild returns all the reminders posted to channels in a guild.
+func (db *DB) GetAllByGuild(guildID discord.GuildID) ([]Reminder, error) {
+	var reminders []Reminder
+	err := db.Select(&reminders, getAllRemindersByGuild, guildID)
+
+	return reminders, err
+}
+
+// GetDueBefore returns all reminders that are due to be sent at or before
+// the provided time, including overdue reminders.
+func (db *DB) GetDueBefore(time time.Time) ([]Reminder, error) {
 	var reminders []Reminder
-	err := db.Select(&reminders, getOverdueReminders)
+	err := db.Select(&reminders, getRemindersDueBefore, time.UTC())
 
 	return reminders, err
 }
+
+// utcTime returns the time converted to UTC, as reminder times are stored
+// without a timezone.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,51 @@
+package reminderdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+const (
+	createSubscribersTableQuery = `
+		CREATE TABLE IF NOT EXISTS ReminderSubscribers(
+			reminderID INT4 NOT NULL,
+			userID     INT8 NOT NULL,
+			PRIMARY KEY(reminderID, userID),
+			FOREIGN KEY(reminderID) REFERENCES Reminders(id) ON DELETE CASCADE
+		)`
+	addSubscriberQuery = `
+		INSERT INTO ReminderSubscribers VALUES($1, $2) ON CONFLICT DO NOTHING`
+	removeSubscriberQuery = `
+		DELETE FROM ReminderSubscribers WHERE reminderID = $1 AND userID = $2`
+	getSubscribersQuery = `
+		SELECT userID FROM ReminderSubscribers WHERE reminderID = $1`
+)
+
+// AddSubscriber subscribes a user to be sent a reminder posted to a guild
+// channel.
+func (db *DB) AddSubscriber(id int32, userID discord.UserID) (bool, error) {
+	res, err := db.Exec(addSubscriberQuery, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	added, err := res.RowsAffected()
+	return added > 0, err
+}
+
+// RemoveSubscriber unsubscribes a user from a reminder posted to a guild
+// channel.
+func (db *DB) RemoveSubscriber(id int32, userID discord.UserID) (bool, error) {
+	res, err := db.Exec(removeSubscriberQuery, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	return removed > 0, err
+}
+
+// GetSubscribers returns the users subscribed to a reminder.
+func (db *DB) GetSubscribers(id int32) ([]discord.UserID, error) {
+	var userIDs []discord.UserID
+	err := db.Select(&userIDs, getSubscribersQuery, id)
+
+	return userIDs, err
+}
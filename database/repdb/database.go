@@ -17,6 +17,13 @@ func New(dbConn *sqlx.DB) *DB {
 
 func (db *DB) createTables() {
 	db.MustExec(createRepTableQuery)
+	db.MustExec(createGuildRepTableQuery)
 	db.MustExec(createRepHistoryTableQuery)
 	db.MustExec(createRepStreaksTableQuery)
+	db.MustExec(addRepStreakFreezeColumnsQuery)
+	db.MustExec(createStreakFreezesTableQuery)
+	db.MustExec(createRepLogTableQuery)
+	db.MustExec(addRepLogGuildColumnQuery)
+	db.MustExec(createRepLogReceiverIndexQuery)
+	db.MustExec(seedRepLogQuery)
 }
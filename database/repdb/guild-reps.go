@@ -0,0 +1,49 @@
+package repdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+// UserRep only holds global rep totals, so reps given in a guild are also
+// counted towards the receiver's rep total in that guild. Reps given before
+// guilds were recorded only count towards global totals.
+const (
+	createGuildRepTableQuery = `
+		CREATE TABLE IF NOT EXISTS GuildUserRep(
+			guildID INT8 NOT NULL,
+			userID  INT8 NOT NULL,
+			rep     INT  NOT NULL,
+			PRIMARY KEY(guildID, userID)
+		)`
+	repGuildUserQuery = `
+		INSERT INTO GuildUserRep VALUES($1, $2, 1)
+		ON CONFLICT(guildID, userID) DO UPDATE SET rep = GuildUserRep.rep + 1
+		RETURNING rep`
+	getGuildUserRepQuery = `
+		SELECT rep FROM GuildUserRep WHERE guildID = $1 AND userID = $2`
+	getTopGuildUsersQuery = `
+		SELECT userID, rep FROM GuildUserRep WHERE guildID = $1
+		ORDER BY rep DESC
+		LIMIT $2`
+	getGuildTotalRepsQuery = `
+		SELECT COALESCE(SUM(rep), 0) FROM GuildUserRep WHERE guildID = $1`
+)
+
+// GetGuildUserRep returns the rep a user has received in a guild.
+func (db *DB) GetGuildUserRep(
+	guildID discord.GuildID, userID discord.UserID) (rep int, err error) {
+
+	return rep, db.Get(&rep, getGuildUserRepQuery, guildID, userID)
+}
+
+// GetTopGuildUsers returns the most repped users in a guild.
+func (db *DB) GetTopGuildUsers(
+	guildID discord.GuildID, limit int64) (users []RepUser, err error) {
+
+	return users, db.Select(&users, getTopGuildUsersQuery, guildID, limit)
+}
+
+// GetGuildTotalReps returns the total number of reps given in a guild.
+func (db *DB) GetGuildTotalReps(
+	guildID discord.GuildID) (reps int64, err error) {
+
+	return reps, db.Get(&reps, getGuildTotalRepsQuery, guildID)
+}
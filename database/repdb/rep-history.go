@@ -22,22 +22,24 @@ const (
 		ON CONFLICT (senderID, receiverID) DO
 		UPDATE SET time = now()`
 	getUserRepsRemainingQuery = `
-		SELECT $1 - COUNT(*) FROM RepHistory 
-		WHERE senderID = $2 AND time::DATE = CURRENT_DATE`
+		SELECT $1 - COUNT(*) FROM RepHistory
+		WHERE senderID = $2 AND time >= $3`
 	getUserRepTimeQuery = `
 		SELECT time FROM RepHistory
 		WHERE senderID = $1 AND receiverID = $2`
 )
 
-// GetUserRepsRemaining returns the number of reps remaining for a user
+// GetUserRepsRemaining returns the number of reps remaining for a user, given
+// the time their daily reps last reset.
 func (db *DB) GetUserRepsRemaining(
-	userID discord.UserID) (remaining int64, err error) {
+	userID discord.UserID, cutoff time.Time) (remaining int64, err error) {
 
 	return remaining, db.Get(
 		&remaining,
 		getUserRepsRemainingQuery,
 		maxRepsRemaining,
 		userID,
+		cutoff,
 	)
 }
 
@@ -0,0 +1,71 @@
+package repdb
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+const maxRepReasonLength = 256
+
+// RepLogEntry is a single rep given from one user to another.
+type RepLogEntry struct {
+	ID         int64          `db:"id"`
+	SenderID   discord.UserID `db:"senderid"`
+	ReceiverID discord.UserID `db:"receiverid"`
+	Time       time.Time      `db:"time"`
+	Reason     string         `db:"reason"`
+	// GuildID is the guild the rep was given in. It is 0 for reps given
+	// before guilds were recorded.
+	GuildID discord.GuildID `db:"guildid"`
+}
+
+const (
+	createRepLogTableQuery = `
+		CREATE TABLE IF NOT EXISTS RepLog(
+			id         BIGSERIAL    NOT NULL,
+			senderID   INT8         NOT NULL,
+			receiverID INT8         NOT NULL,
+			time       TIMESTAMPTZ  NOT NULL DEFAULT now(),
+			reason     VARCHAR(256) NOT NULL DEFAULT '',
+			guildID    INT8         NOT NULL DEFAULT 0,
+			PRIMARY KEY (id)
+		)`
+	addRepLogGuildColumnQuery = `
+		ALTER TABLE RepLog
+		ADD COLUMN IF NOT EXISTS guildID INT8 NOT NULL DEFAULT 0`
+	createRepLogReceiverIndexQuery = `
+		CREATE INDEX IF NOT EXISTS RepLogReceiverIndex
+		ON RepLog(receiverID, time DESC)`
+	// RepHistory only keeps the latest rep between each pair of users, so
+	// the log is seeded with those reps the first time it is created.
+	seedRepLogQuery = `
+		INSERT INTO RepLog(senderID, receiverID, time)
+		SELECT senderID, receiverID, time FROM RepHistory
+		WHERE NOT EXISTS (SELECT 1 FROM RepLog)`
+	addRepLogEntryQuery = `
+		INSERT INTO RepLog(guildID, senderID, receiverID, reason)
+		VALUES($1, $2, $3, $4)`
+	getReceivedRepsQuery = `
+		SELECT * FROM RepLog WHERE receiverID = $1
+		ORDER BY time DESC, id DESC
+		LIMIT $2`
+	getReceivedRepsCountQuery = `
+		SELECT COUNT(*) FROM RepLog WHERE receiverID = $1`
+)
+
+// GetReceivedReps returns the most recent reps a user has received, newest
+// first.
+func (db *DB) GetReceivedReps(
+	receiverID discord.UserID, limit int64) (reps []RepLogEntry, err error) {
+
+	return reps, db.Select(&reps, getReceivedRepsQuery, receiverID, limit)
+}
+
+// GetReceivedRepsCount returns the number of reps a user has received that
+// are recorded in the rep log.
+func (db *DB) GetReceivedRepsCount(
+	receiverID discord.UserID) (count int64, err error) {
+
+	return count, db.Get(&count, getReceivedRepsCountQuery, receiverID)
+}
@@ -11,6 +11,20 @@ type RepStreak struct {
 	UserID1  discord.UserID `db:"userid1"`
 	UserID2  discord.UserID `db:"userid2"`
 	FirstRep time.Time      `db:"firstrep"`
+	// FreezeMilestone is the number of weeks into the streak the users were
+	// last given streak freezes at.
+	FreezeMilestone int `db:"freezemilestone"`
+	// FrozenUntil is when the streak freeze protecting the streak from
+	// expiring ends, if one has been used.
+	FrozenUntil *time.Time `db:"frozenuntil"`
+	// WarnedExpiry is the expiry time the users were last warned about.
+	WarnedExpiry *time.Time `db:"warnedexpiry"`
+}
+
+// Frozen returns whether a streak freeze is currently protecting the streak
+// from expiring.
+func (rs RepStreak) Frozen() bool {
+	return rs.FrozenUntil != nil && rs.FrozenUntil.After(time.Now())
 }
 
 // Days returns the number of days elapsed since the start of the streak.
@@ -40,33 +54,53 @@ func (rs RepStreak) Equals(streak RepStreak) bool {
 const (
 	createRepStreaksTableQuery = `
 		CREATE TABLE IF NOT EXISTS RepStreaks(
-			userID1  INT8        NOT NULL,
-			userID2  INT8        NOT NULL,
-			firstRep TIMESTAMPTZ NOT NULL DEFAULT now(),
+			userID1         INT8        NOT NULL,
+			userID2         INT8        NOT NULL,
+			firstRep        TIMESTAMPTZ NOT NULL DEFAULT now(),
+			freezeMilestone INT         NOT NULL DEFAULT 0,
+			frozenUntil     TIMESTAMPTZ,
+			warnedExpiry    TIMESTAMPTZ,
 			CHECK (userID1 <> userID2),
 			CHECK (userID1 < userID2),
 			PRIMARY KEY (userID1, userID2)
 		)`
+	addRepStreakFreezeColumnsQuery = `
+		ALTER TABLE RepStreaks
+		ADD COLUMN IF NOT EXISTS freezeMilestone INT NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS frozenUntil TIMESTAMPTZ,
+		ADD COLUMN IF NOT EXISTS warnedExpiry TIMESTAMPTZ`
+	// a streak is restarted if the users have not both repped each other
+	// since the streak window, unless a streak freeze is protecting it.
 	addOrUpdateRepStreakQuery = `
-		INSERT INTO RepStreaks VALUES($1, $2)
-		ON CONFLICT(userID1, userID2) DO
-		UPDATE SET firstRep = CASE
-			WHEN (
+		WITH broken AS (
+			SELECT (
 				SELECT COUNT(*) FROM RepHistory AS rh
-				WHERE rh.senderID IN ($1, $2) 
+				WHERE rh.senderID IN ($1, $2)
 					AND rh.receiverID IN ($1, $2)
-					AND now() - rh.time < INTERVAL '36 hours'
-			) < 2 THEN now()
-			ELSE RepStreaks.firstRep
-		END`
+					AND rh.time >= $3
+			) < 2 AND NOT EXISTS (
+				SELECT 1 FROM RepStreaks
+				WHERE userID1 = $1 AND userID2 = $2 AND frozenUntil > now()
+			) AS restart
+		)
+		INSERT INTO RepStreaks(userID1, userID2) VALUES($1, $2)
+		ON CONFLICT(userID1, userID2) DO
+		UPDATE SET
+			firstRep = CASE WHEN (SELECT restart FROM broken)
+				THEN now() ELSE RepStreaks.firstRep END,
+			freezeMilestone = CASE WHEN (SELECT restart FROM broken)
+				THEN 0 ELSE RepStreaks.freezeMilestone END,
+			frozenUntil = CASE WHEN (SELECT restart FROM broken)
+				THEN NULL ELSE RepStreaks.frozenUntil END`
 	updateRepStreaksQuery = `
 		DELETE FROM RepStreaks AS rs
-			WHERE firstRep < now() - interval '36 hours'
+			WHERE firstRep < $1
+			AND (rs.frozenUntil IS NULL OR rs.frozenUntil <= now())
 			AND (
 				SELECT COUNT(*) FROM RepHistory AS rh
-				WHERE rh.senderID IN (rs.userID1, rs.userID2) 
+				WHERE rh.senderID IN (rs.userID1, rs.userID2)
 					AND rh.receiverID IN (rs.userID1, rs.userID2)
-					AND now() - rh.time < INTERVAL '36 hours'
+					AND rh.time >= $1
 			) < 2`
 	getUserStreakQuery = `
 		SELECT * FROM RepStreaks 
@@ -80,21 +114,12 @@ const (
 	getEntriesSizeQuery = `
 		SELECT COUNT(*) FROM RepStreaks 
 		WHERE now() - firstRep > INTERVAL '24 hours'`
-	getTimeToStreakExpiryQuery = `		
-		SELECT EXTRACT(EPOCH FROM AGE(
-			(
-				SELECT min(time) FROM RepHistory AS rh
-				WHERE rh.senderID IN ($1, $2) 
-					AND rh.receiverID IN ($1, $2)
-			), 
-			now() - INTERVAL '36 hours'
-		))::BIGINT`
 )
 
-// UpdateRepStreaks clears any rep streaks that have fallen past the max rep
-// age time.
-func (db *DB) UpdateRepStreaks() (int64, error) {
-	res, err := db.Exec(updateRepStreaksQuery)
+// UpdateRepStreaks clears any rep streaks where the users have not both
+// repped each other since the provided time.
+func (db *DB) UpdateRepStreaks(since time.Time) (int64, error) {
+	res, err := db.Exec(updateRepStreaksQuery, since)
 	if err != nil {
 		return 0, err
 	}
@@ -125,16 +150,3 @@ func (db *DB) GetTopStreaks(limit int64) (streaks []RepStreak, err error) {
 func (db *DB) GetTotalStreaks() (count int, err error) {
 	return count, db.Get(&count, getEntriesSizeQuery)
 }
-
-// Returns how long until the streak will expire if the earlier user to rep
-// doesn't rep soon.
-func (db *DB) GetTimeToStreakExpiry(
-	streak RepStreak) (d time.Duration, err error) {
-
-	return d * time.Second, db.Get(
-		&d,
-		getTimeToStreakExpiryQuery,
-		streak.UserID1,
-		streak.UserID2,
-	)
-}
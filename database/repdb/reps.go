@@ -2,6 +2,7 @@ package repdb
 
 import (
 	"errors"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/jmoiron/sqlx"
@@ -37,9 +38,12 @@ const (
 
 // addOrUpdateRepStreak adds a rep streak start entry to the database if it
 // doesn't exist, or updates the time according to whether the rep streak should
-// be reset or continue, based on the users' rep history.
+// be reset or continue, based on whether the users have both repped each other
+// since the start of the streak window.
 func addOrUpdateRepStreak(
-	tx *sqlx.Tx, senderID, targetID discord.UserID) (bool, error) {
+	tx *sqlx.Tx,
+	senderID, targetID discord.UserID,
+	streakWindow time.Time) (bool, error) {
 
 	var userID1, userID2 discord.UserID
 	if senderID < targetID {
@@ -48,7 +52,9 @@ func addOrUpdateRepStreak(
 		userID1, userID2 = targetID, senderID
 	}
 
-	res, err := tx.Exec(addOrUpdateRepStreakQuery, userID1, userID2)
+	res, err := tx.Exec(
+		addOrUpdateRepStreakQuery, userID1, userID2, streakWindow,
+	)
 	if err != nil {
 		return false, err
 	}
@@ -57,11 +63,22 @@ func addOrUpdateRepStreak(
 	return added > 0, err
 }
 
-// RepUser adds a rep to a user.
-func (db *DB) RepUser(senderID, targetID discord.UserID) (rep int, err error) {
+// RepUser adds a rep to a user, recording it in the rep log with the guild
+// it was given in and an optional reason. The rep is also counted towards the
+// user's rep in the guild, if the guild ID is valid. The users' rep streak
+// continues if they have both repped each other since the streak window.
+func (db *DB) RepUser(
+	guildID discord.GuildID,
+	senderID, targetID discord.UserID,
+	reason string,
+	streakWindow time.Time) (rep int, err error) {
+
 	if senderID == targetID {
 		return 0, errors.New("sender and target rep users cannot be the same")
 	}
+	if len([]rune(reason)) > maxRepReasonLength {
+		return 0, errors.New("rep reason is too long")
+	}
 
 	tx, err := db.Beginx()
 	if err != nil {
@@ -80,7 +97,21 @@ func (db *DB) RepUser(senderID, targetID discord.UserID) (rep int, err error) {
 		return 0, err
 	}
 
-	_, err = addOrUpdateRepStreak(tx, senderID, targetID)
+	if guildID.IsValid() {
+		_, err = tx.Exec(repGuildUserQuery, guildID, targetID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(
+		addRepLogEntryQuery, guildID, senderID, targetID, reason,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = addOrUpdateRepStreak(tx, senderID, targetID, streakWindow)
 	if err != nil {
 		return 0, err
 	}
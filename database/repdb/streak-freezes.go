@@ -0,0 +1,193 @@
+package repdb
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// RepStreakStatus is a rep streak along with the time each user in the
+// streak last repped the other.
+type RepStreakStatus struct {
+	RepStreak    `db:""`
+	User1LastRep *time.Time `db:"user1lastrep"`
+	User2LastRep *time.Time `db:"user2lastrep"`
+}
+
+// LastRep returns the time a user in the streak last repped the other user,
+// or nil if they have not repped them.
+func (s RepStreakStatus) LastRep(userID discord.UserID) *time.Time {
+	switch userID {
+	case s.UserID1:
+		return s.User1LastRep
+	case s.UserID2:
+		return s.User2LastRep
+	default:
+		return nil
+	}
+}
+
+const (
+	createStreakFreezesTableQuery = `
+		CREATE TABLE IF NOT EXISTS RepStreakFreezes(
+			userID  INT8 NOT NULL,
+			freezes INT  NOT NULL DEFAULT 0,
+			PRIMARY KEY (userID)
+		)`
+	getStreakStatusesQuery = `
+		SELECT rs.*,
+			(
+				SELECT time FROM RepHistory
+				WHERE senderID = rs.userID1 AND receiverID = rs.userID2
+			) AS user1LastRep,
+			(
+				SELECT time FROM RepHistory
+				WHERE senderID = rs.userID2 AND receiverID = rs.userID1
+			) AS user2LastRep
+		FROM RepStreaks AS rs`
+	getStreakStatusQuery = getStreakStatusesQuery + `
+		WHERE rs.userID1 IN ($1, $2) AND rs.userID2 IN ($1, $2)`
+	getUserStreakStatusesQuery = getStreakStatusesQuery + `
+		WHERE $1 IN (rs.userID1, rs.userID2)`
+	getStreakFreezesQuery = `
+		SELECT COALESCE(
+			(SELECT freezes FROM RepStreakFreezes WHERE userID = $1), 0
+		)`
+	setFreezeMilestoneQuery = `
+		UPDATE RepStreaks SET freezeMilestone = $3
+		WHERE userID1 = $1 AND userID2 = $2 AND freezeMilestone < $3`
+	addStreakFreezeQuery = `
+		INSERT INTO RepStreakFreezes VALUES($1, 1)
+		ON CONFLICT(userID) DO UPDATE
+		SET freezes = LEAST(RepStreakFreezes.freezes + 1, $2)`
+	useStreakFreezeQuery = `
+		UPDATE RepStreakFreezes SET freezes = freezes - 1
+		WHERE userID = $1 AND freezes > 0`
+	setFrozenUntilQuery = `
+		UPDATE RepStreaks SET frozenUntil = $3
+		WHERE userID1 = $1 AND userID2 = $2
+			AND (frozenUntil IS NULL OR frozenUntil <= now())`
+	setWarnedExpiryQuery = `
+		UPDATE RepStreaks SET warnedExpiry = $3
+		WHERE userID1 = $1 AND userID2 = $2`
+)
+
+// GetStreakStatuses returns all rep streaks along with the time each user
+// last repped the other.
+func (db *DB) GetStreakStatuses() (statuses []RepStreakStatus, err error) {
+	return statuses, db.Select(&statuses, getStreakStatusesQuery)
+}
+
+// GetUserStreakStatuses returns all of a user's rep streaks along with the
+// time each user last repped the other.
+func (db *DB) GetUserStreakStatuses(
+	userID discord.UserID) (statuses []RepStreakStatus, err error) {
+
+	return statuses, db.Select(&statuses, getUserStreakStatusesQuery, userID)
+}
+
+// GetStreakStatus returns the rep streak between two users along with the
+// time each user last repped the other.
+func (db *DB) GetStreakStatus(
+	userID1, userID2 discord.UserID) (*RepStreakStatus, error) {
+
+	var status RepStreakStatus
+	err := db.Get(&status, getStreakStatusQuery, userID1, userID2)
+
+	return &status, err
+}
+
+// GetStreakFreezes returns the number of streak freezes a user has.
+func (db *DB) GetStreakFreezes(userID discord.UserID) (freezes int, err error) {
+	return freezes, db.Get(&freezes, getStreakFreezesQuery, userID)
+}
+
+// AwardStreakFreezes gives both users in a streak a streak freeze for
+// reaching a milestone in the streak, up to the max number of freezes a user
+// can hold. Freezes are only given once for each milestone.
+func (db *DB) AwardStreakFreezes(
+	streak RepStreak, milestone, maxFreezes int) (bool, error) {
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		setFreezeMilestoneQuery, streak.UserID1, streak.UserID2, milestone,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil || updated < 1 {
+		return false, err
+	}
+
+	for _, userID := range []discord.UserID{streak.UserID1, streak.UserID2} {
+		_, err = tx.Exec(addStreakFreezeQuery, userID, maxFreezes)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// UseStreakFreeze uses one of a user's streak freezes to protect a streak
+// from expiring until the provided time. It returns false if the user has no
+// streak freezes or the streak is already protected by a streak freeze, in
+// which case no freeze is used.
+func (db *DB) UseStreakFreeze(
+	streak RepStreak, userID discord.UserID, until time.Time) (bool, error) {
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(useStreakFreezeQuery, userID)
+	if err != nil {
+		return false, err
+	}
+
+	used, err := res.RowsAffected()
+	if err != nil || used < 1 {
+		return false, err
+	}
+
+	res, err = tx.Exec(
+		setFrozenUntilQuery, streak.UserID1, streak.UserID2, until,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	frozen, err := res.RowsAffected()
+	if err != nil || frozen < 1 {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// SetStreakWarnedExpiry sets the expiry time the users in a streak were last
+// warned about.
+func (db *DB) SetStreakWarnedExpiry(
+	streak RepStreak, expiry time.Time) (bool, error) {
+
+	res, err := db.Exec(
+		setWarnedExpiryQuery, streak.UserID1, streak.UserID2, expiry,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := res.RowsAffected()
+	return set > 0, err
+}
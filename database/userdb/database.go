@@ -0,0 +1,20 @@
+package userdb
+
+import "github.com/jmoiron/sqlx"
+
+// DB wraps an sqlx database instance with helper methods for user settings
+// querying.
+type DB struct {
+	*sqlx.DB
+}
+
+// New returns a new instance of a user settings database.
+func New(dbConn *sqlx.DB) *DB {
+	db := &DB{dbConn}
+	db.createTables()
+	return db
+}
+
+func (db *DB) createTables() {
+	db.MustExec(createUserSettingsTableQuery)
+}
@@ -0,0 +1,30 @@
+package userdb
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+const (
+	createUserSettingsTableQuery = `
+		CREATE TABLE IF NOT EXISTS UserSettings(
+			userID   INT8        NOT NULL PRIMARY KEY,
+			timezone VARCHAR(64) NOT NULL DEFAULT ''
+		)`
+
+	setTimezoneQuery = `
+		INSERT INTO UserSettings (userID, timezone) VALUES($1, $2)
+		ON CONFLICT(userID) DO UPDATE SET timezone = $2`
+	getTimezoneQuery = `SELECT timezone FROM UserSettings WHERE userID = $1`
+)
+
+// SetTimezone sets the IANA timezone name for a user.
+func (db *DB) SetTimezone(userID discord.UserID, timezone string) error {
+	_, err := db.Exec(setTimezoneQuery, userID, timezone)
+	return err
+}
+
+// GetTimezone returns the IANA timezone name set for a user.
+func (db *DB) GetTimezone(userID discord.UserID) (string, error) {
+	var timezone string
+	err := db.Get(&timezone, getTimezoneQuery, userID)
+
+	return timezone, err
+}
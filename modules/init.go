@@ -13,6 +13,7 @@ import (
 	"github.com/twoscott/haseul-bot-2/modules/reminders"
 	"github.com/twoscott/haseul-bot-2/modules/roles"
 	"github.com/twoscott/haseul-bot-2/modules/server"
+	"github.com/twoscott/haseul-bot-2/modules/settings"
 	"github.com/twoscott/haseul-bot-2/modules/user"
 	"github.com/twoscott/haseul-bot-2/modules/youtube"
 	"github.com/twoscott/haseul-bot-2/router"
@@ -31,6 +32,7 @@ func Init(rt *router.Router) {
 	reminders.Init(rt)
 	roles.Init(rt)
 	server.Init(rt)
+	settings.Init(rt)
 	user.Init(rt)
 	youtube.Init(rt)
 }
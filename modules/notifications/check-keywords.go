@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
@@ -16,19 +17,34 @@ import (
 )
 
 type notificationMatch struct {
-	userID  discord.UserID
-	keyword string
+	userID   discord.UserID
+	keyword  string
+	guildID  discord.GuildID
+	cooldown time.Duration
+	delivery notifdb.DeliveryMethod
+}
+
+type notificationTarget struct {
+	userID   discord.UserID
+	delivery notifdb.DeliveryMethod
 }
 
 func checkKeywords(
-	rt *router.Router, msg discord.Message, _ *discord.Member) {
+	rt *router.Router, msg discord.Message, member *discord.Member) {
 
 	if len(msg.Content) < 1 {
 		return
 	}
 
+	var roleIDs []discord.RoleID
+	if member != nil {
+		roleIDs = member.RoleIDs
+	}
+
+	isBot := msg.Author.Bot || msg.WebhookID.IsValid()
+
 	notifs, err := db.Notifications.GetAllChecking(
-		msg.Author.ID, msg.GuildID, msg.ChannelID,
+		msg.Author.ID, msg.GuildID, msg.ChannelID, isBot, roleIDs,
 	)
 	if err != nil {
 		log.Println(err)
@@ -64,12 +80,32 @@ func checkMatch(
 	noti notifdb.Notification,
 	content string) {
 
-	rgxString := regexp.QuoteMeta(noti.Keyword)
+	if !keywordMatches(noti.Keyword, noti.Type, content) {
+		return
+	}
+
+	matchChan <- notificationMatch{
+		userID:   noti.UserID,
+		keyword:  noti.Keyword,
+		guildID:  noti.GuildID,
+		cooldown: noti.Cooldown(),
+		delivery: noti.Delivery,
+	}
+}
+
+// keywordMatches returns whether the content mentions the keyword, matched
+// according to the notification type.
+func keywordMatches(
+	keyword string,
+	notiType notifdb.NotificationType,
+	content string) bool {
 
-	switch noti.Type {
+	rgxString := regexp.QuoteMeta(keyword)
+
+	switch notiType {
 	case notifdb.NormalNotification:
-		plural := util.PluralSuffix(noti.Keyword)
-		possessive := util.PossessiveSuffix(noti.Keyword)
+		plural := util.PluralSuffix(keyword)
+		possessive := util.PossessiveSuffix(keyword)
 		rgxString = rgxString + `(?:` + possessive + `|` + plural + `)?`
 		rgxString = `(?i)(^|\W)` + rgxString + `($|\W)`
 	case notifdb.LenientNotification:
@@ -81,72 +117,60 @@ func checkMatch(
 	rgx, err := regexp.Compile(rgxString)
 	if err != nil {
 		log.Println(err)
-		return
-	}
-
-	match := rgx.MatchString(content)
-	if !match {
-		return
+		return false
 	}
 
-	matchChan <- notificationMatch{
-		userID:  noti.UserID,
-		keyword: noti.Keyword,
-	}
+	return rgx.MatchString(content)
 }
 
+// sendNotifications sends each user a notification for the keywords they
+// matched. Cooldowns are started as matches are found, so that concurrent
+// messages can't both notify a user, and are cancelled again for any
+// notification that isn't delivered.
 func sendNotifications(
 	rt *router.Router,
 	matchChan <-chan notificationMatch,
 	msg discord.Message) {
 
-	userMatchSets := make(map[discord.UserID]map[string]struct{})
-	exists := struct{}{}
+	targetMatches := make(map[notificationTarget][]notificationMatch)
 
 	for match := range matchChan {
-		if _, ok := userMatchSets[match.userID]; !ok {
-			userMatchSets[match.userID] = make(map[string]struct{})
+		if !cooldowns.TryStart(match, msg.ChannelID, match.cooldown) {
+			continue
 		}
-		userMatchSets[match.userID][match.keyword] = exists
-	}
-
-	for userID, matchSet := range userMatchSets {
-		matches := make([]string, len(matchSet))
 
-		i := 0
-		for keyword := range matchSet {
-			matches[i] = keyword
-			i++
-		}
+		target := notificationTarget{match.userID, match.delivery}
+		targetMatches[target] = append(targetMatches[target], match)
+	}
 
-		go sendNotification(rt, msg, userID, matches)
+	for target, matches := range targetMatches {
+		go sendNotification(rt, msg, target, matches)
 	}
 }
 
 func sendNotification(
 	rt *router.Router,
 	msg discord.Message,
-	userID discord.UserID,
-	matches []string) {
+	target notificationTarget,
+	matches []notificationMatch) {
 
-	chString := msg.ChannelID.String()
 	channel, err := rt.State.Channel(msg.ChannelID)
-	if err == nil {
-		chString = dctools.GetChannelString(*channel)
-	}
-
-	if !canSeeChannel(rt, *channel, userID) {
+	if err != nil {
+		log.Println(err)
+		cooldowns.Cancel(matches, msg.ChannelID)
 		return
 	}
 
-	dmChannel, err := rt.State.CreatePrivateChannel(userID)
-	if err != nil {
-		log.Println(err)
+	if !canSeeChannel(rt, *channel, target.userID) {
+		cooldowns.Cancel(matches, msg.ChannelID)
 		return
 	}
 
+	chString := dctools.GetChannelString(*channel)
+	keywords := matchedKeywords(matches)
+
 	name := msg.Author.DisplayOrUsername()
-	matchString := strings.Join(matches, "`, `")
+	matchString := strings.Join(keywords, "`, `")
 	content := fmt.Sprintf("💬 %s mentioned `%s`",
 		dctools.Bold(name), matchString,
 	)
@@ -170,7 +194,7 @@ func sendNotification(
 		Color:     dctools.EmbedColour(colour),
 	}
 
-	rt.State.SendMessageComplex(dmChannel.ID, api.SendMessageData{
+	data := api.SendMessageData{
 		Content: content,
 		Embeds:  []discord.Embed{embed},
 		Components: discord.Components(
@@ -181,7 +205,35 @@ func sendNotification(
 				},
 			},
 		),
-	})
+	}
+
+	if !deliverNotification(rt, msg, target.userID, target.delivery, data) {
+		cooldowns.Cancel(matches, msg.ChannelID)
+		return
+	}
+
+	err = db.Notifications.AddHistoryEntries(target.userID, keywords, msg)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// matchedKeywords returns each distinct keyword out of a user's matches. The
+// same keyword can match in both a server and global scope.
+func matchedKeywords(matches []notificationMatch) []string {
+	keywords := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+
+	for _, m := range matches {
+		if _, ok := seen[m.keyword]; ok {
+			continue
+		}
+
+		seen[m.keyword] = struct{}{}
+		keywords = append(keywords, m.keyword)
+	}
+
+	return keywords
 }
 
 func canSeeChannel(
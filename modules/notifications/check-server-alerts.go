@@ -0,0 +1,207 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+const buttonIDDeleteAlertedMessage = "DELETE_ALERTED_MESSAGE"
+
+func checkServerAlerts(
+	rt *router.Router, msg discord.Message, _ *discord.Member) {
+
+	if len(msg.Content) < 1 {
+		return
+	}
+
+	alerts, err := db.Notifications.GetCheckingServerAlerts(msg.GuildID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(alerts) < 1 {
+		return
+	}
+
+	matches := make([]string, 0)
+	for _, alert := range alerts {
+		if keywordMatches(alert.Keyword, alert.Type, msg.Content) {
+			matches = append(matches, alert.Keyword)
+		}
+	}
+	if len(matches) < 1 {
+		return
+	}
+
+	config, err := db.Notifications.GetServerAlertConfig(msg.GuildID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if config.ChannelID == msg.ChannelID {
+		return
+	}
+
+	sendServerAlert(rt, msg, config.ChannelID, matches)
+}
+
+func sendServerAlert(
+	rt *router.Router,
+	msg discord.Message,
+	alertChannelID discord.ChannelID,
+	matches []string) {
+
+	chString := msg.ChannelID.String()
+	channel, err := rt.State.Channel(msg.ChannelID)
+	if err == nil {
+		chString = dctools.GetChannelString(*channel)
+	}
+
+	matchString := strings.Join(matches, "`, `")
+	content := fmt.Sprintf(
+		"🚨 %s mentioned `%s` in %s",
+		msg.Author.Mention(), matchString, msg.ChannelID.Mention(),
+	)
+
+	colour, _ := rt.State.MemberColor(msg.GuildID, msg.Author.ID)
+	embed := discord.Embed{
+		Author: &discord.EmbedAuthor{
+			Name: msg.Author.Tag(),
+			Icon: msg.Author.AvatarURL(),
+		},
+		Description: msg.Content,
+		Footer: &discord.EmbedFooter{
+			Text: dctools.SeparateEmbedFooter(
+				chString,
+				"User ID: "+msg.Author.ID.String(),
+			),
+		},
+		Timestamp: msg.Timestamp,
+		Color:     dctools.EmbedColour(colour),
+	}
+
+	_, err = rt.State.SendMessageComplex(alertChannelID, api.SendMessageData{
+		Content:         content,
+		Embeds:          []discord.Embed{embed},
+		Components:      alertComponents(msg, false),
+		AllowedMentions: dctools.NoMentions,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func alertComponents(
+	msg discord.Message, deleted bool) discord.ContainerComponents {
+
+	deleteLabel := "Delete Message"
+	if deleted {
+		deleteLabel = "Message Deleted"
+	}
+
+	return discord.Components(
+		&discord.ActionRowComponent{
+			&discord.ButtonComponent{
+				Label: "Jump to Message",
+				Style: discord.LinkButtonStyle(msg.URL()),
+			},
+			&discord.ButtonComponent{
+				Label: deleteLabel,
+				CustomID: discord.ComponentID(fmt.Sprintf(
+					"%s:%d:%d",
+					buttonIDDeleteAlertedMessage, msg.ChannelID, msg.ID,
+				)),
+				Style:    discord.DangerButtonStyle(),
+				Disabled: deleted,
+			},
+		},
+	)
+}
+
+func handleDeleteAlertedMessage(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	data *discord.ButtonInteraction) {
+
+	args := strings.Split(string(data.CustomID), ":")
+	if len(args) != 3 || args[0] != buttonIDDeleteAlertedMessage {
+		return
+	}
+
+	channelSnowflake, err := discord.ParseSnowflake(args[1])
+	if err != nil {
+		return
+	}
+	messageSnowflake, err := discord.ParseSnowflake(args[2])
+	if err != nil {
+		return
+	}
+
+	msg := discord.Message{
+		ID:        discord.MessageID(messageSnowflake),
+		ChannelID: discord.ChannelID(channelSnowflake),
+		GuildID:   interaction.GuildID,
+	}
+
+	permissions, err := rt.State.Permissions(
+		msg.ChannelID, interaction.SenderID(),
+	)
+	if err != nil || !dctools.HasAnyPermOrAdmin(
+		permissions, discord.PermissionManageMessages) {
+
+		respondAlertButton(rt, interaction, router.Warning(
+			"You need the Manage Messages permission in the channel "+
+				"to delete this message.",
+		))
+		return
+	}
+
+	err = rt.State.DeleteMessage(
+		msg.ChannelID,
+		msg.ID,
+		api.AuditLogReason("Deleted from keyword alert by "+
+			interaction.Sender().Tag()),
+	)
+	if err != nil && !dctools.ErrUnknownMessage(err) {
+		log.Println(err)
+		respondAlertButton(rt, interaction, router.Error(
+			"Error occurred while deleting the message.",
+		))
+		return
+	}
+
+	content := interaction.Message.Content + fmt.Sprintf(
+		"\n🗑️ Deleted by %s", interaction.SenderID().Mention(),
+	)
+	components := alertComponents(msg, true)
+
+	rt.State.RespondInteraction(
+		interaction.ID,
+		interaction.Token,
+		*dctools.UpdateMessageResponse(api.InteractionResponseData{
+			Content:         option.NewNullableString(content),
+			Components:      &components,
+			AllowedMentions: dctools.NoMentions,
+		}),
+	)
+}
+
+func respondAlertButton(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	response router.CmdResponse) {
+
+	dctools.MessageRespond(rt.State, interaction,
+		api.InteractionResponseData{
+			Content: option.NewNullableString(response.String()),
+			Flags:   discord.EphemeralMessage,
+		},
+	)
+}
@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+type cooldownTracker struct {
+	userID    discord.UserID
+	keyword   string
+	guildID   discord.GuildID
+	channelID discord.ChannelID
+}
+
+type cooldownCache struct {
+	mu       sync.Mutex
+	lastSent map[cooldownTracker]time.Time
+	maxAge   time.Duration
+}
+
+// TryStart returns whether a notification for the keyword is off cooldown in
+// the channel, and if so, starts a new cooldown from now.
+func (c *cooldownCache) TryStart(
+	match notificationMatch,
+	channelID discord.ChannelID,
+	cooldown time.Duration) bool {
+
+	if cooldown <= 0 {
+		return true
+	}
+
+	tracker := cooldownTracker{
+		userID:    match.userID,
+		keyword:   match.keyword,
+		guildID:   match.guildID,
+		channelID: channelID,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sent, ok := c.lastSent[tracker]
+	if ok && time.Since(sent) < cooldown {
+		return false
+	}
+
+	c.lastSent[tracker] = time.Now()
+	return true
+}
+
+// Cancel removes the cooldowns started for matches in the channel, for when a
+// notification for the matches could not be sent.
+func (c *cooldownCache) Cancel(
+	matches []notificationMatch, channelID discord.ChannelID) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, match := range matches {
+		delete(c.lastSent, cooldownTracker{
+			userID:    match.userID,
+			keyword:   match.keyword,
+			guildID:   match.guildID,
+			channelID: channelID,
+		})
+	}
+}
+
+// ClearCache clears any cooldowns older than the max cooldown age.
+func (c *cooldownCache) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for tracker, sent := range c.lastSent {
+		if time.Since(sent) > c.maxAge {
+			delete(c.lastSent, tracker)
+			deleted++
+		}
+	}
+
+	log.Printf("Deleted %d notification cooldowns from the cache\n", deleted)
+}
+
+// ClearJob starts a job that clears the cache at the provided interval.
+func (c *cooldownCache) ClearJob(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		c.ClearCache()
+	}
+}
+
+func newCooldownCache(maxAge time.Duration) *cooldownCache {
+	return &cooldownCache{
+		lastSent: make(map[cooldownTracker]time.Time),
+		maxAge:   maxAge,
+	}
+}
@@ -0,0 +1,252 @@
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+// deliveryFailureThreshold is the number of consecutive failed deliveries
+// after which the user is told their preferred delivery method is failing.
+const deliveryFailureThreshold = 3
+
+var errDeliveryUnavailable = errors.New("delivery method is not set up")
+
+// deliverNotification attempts to deliver a notification using the preferred
+// delivery method, falling back to each other available method in turn.
+// Threads are only ever used in the guild the message was sent in. It returns
+// whether the notification was delivered by any method.
+func deliverNotification(
+	rt *router.Router,
+	msg discord.Message,
+	userID discord.UserID,
+	preferred notifdb.DeliveryMethod,
+	data api.SendMessageData) bool {
+
+	err := sendByMethod(rt, msg, userID, preferred, data)
+	if err == nil {
+		resetDeliveryFailures(userID, preferred)
+		return true
+	}
+	if !errors.Is(err, errDeliveryUnavailable) {
+		log.Println(err)
+	}
+
+	failures, err := db.Notifications.AddDeliveryFailure(userID, preferred)
+	if err != nil {
+		log.Println(err)
+	} else {
+		deliveryFailures.Add(notificationTarget{userID, preferred})
+	}
+
+	warn := failures > 0 && failures%deliveryFailureThreshold == 0
+	if warn {
+		data.Content += fmt.Sprintf(
+			"\n\n⚠️ Your notifications could not be delivered by %s %d "+
+				"times in a row, so they were delivered here instead. "+
+				"Use `/notifications delivery status` to check your settings.",
+			preferred, failures,
+		)
+	}
+
+	for _, method := range notifdb.DeliveryMethods {
+		if method == preferred {
+			continue
+		}
+
+		err := sendByMethod(rt, msg, userID, method, data)
+		if err == nil {
+			return true
+		}
+		if !errors.Is(err, errDeliveryUnavailable) {
+			log.Println(err)
+		}
+	}
+
+	if warn {
+		sendDeliveryFailureNotice(rt, msg.GuildID, userID, preferred, failures)
+	}
+
+	return false
+}
+
+// sendDeliveryFailureNotice lets a user know their notifications are not
+// being delivered by any method, by mentioning them in the notification
+// channel of the guild the notification came from, if it has one.
+func sendDeliveryFailureNotice(
+	rt *router.Router,
+	guildID discord.GuildID,
+	userID discord.UserID,
+	method notifdb.DeliveryMethod,
+	failures int32) {
+
+	if !guildID.IsValid() {
+		return
+	}
+
+	channelID, err := db.Notifications.GetThreadChannel(guildID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Println(err)
+		}
+		return
+	}
+
+	_, err = rt.State.SendMessageComplex(channelID, api.SendMessageData{
+		Content: fmt.Sprintf(
+			"⚠️ %s, your keyword notifications could not be delivered by %s "+
+				"%d times in a row, and no other delivery method worked. "+
+				"Use `/notifications delivery status` to check your settings.",
+			userID.Mention(), method, failures,
+		),
+		AllowedMentions: &api.AllowedMentions{Users: []discord.UserID{userID}},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func sendByMethod(
+	rt *router.Router,
+	msg discord.Message,
+	userID discord.UserID,
+	method notifdb.DeliveryMethod,
+	data api.SendMessageData) error {
+
+	switch method {
+	case notifdb.DMDelivery:
+		return sendDMNotification(rt, userID, data)
+	case notifdb.ThreadDelivery:
+		return sendThreadNotification(rt, userID, msg.GuildID, data)
+	case notifdb.WebhookDelivery:
+		return sendWebhookNotification(userID, msg.URL(), data)
+	}
+
+	return errDeliveryUnavailable
+}
+
+func sendDMNotification(
+	rt *router.Router, userID discord.UserID, data api.SendMessageData) error {
+
+	dmChannel, err := rt.State.CreatePrivateChannel(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = rt.State.SendMessageComplex(dmChannel.ID, data)
+	return err
+}
+
+func sendThreadNotification(
+	rt *router.Router,
+	userID discord.UserID,
+	guildID discord.GuildID,
+	data api.SendMessageData) error {
+
+	if !guildID.IsValid() {
+		return errDeliveryUnavailable
+	}
+
+	threadID, err := db.Notifications.GetThread(userID, guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		threadID, err = createNotificationThread(rt, userID, guildID)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = rt.State.SendMessageComplex(threadID, data)
+	if !dctools.ErrUnknownChannel(err) {
+		return err
+	}
+
+	// the thread was deleted, so create a new one and try again.
+	threadID, err = createNotificationThread(rt, userID, guildID)
+	if err != nil {
+		return err
+	}
+
+	_, err = rt.State.SendMessageComplex(threadID, data)
+	return err
+}
+
+func createNotificationThread(
+	rt *router.Router,
+	userID discord.UserID,
+	guildID discord.GuildID) (discord.ChannelID, error) {
+
+	channelID, err := db.Notifications.GetThreadChannel(guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return discord.NullChannelID, errDeliveryUnavailable
+	}
+	if err != nil {
+		return discord.NullChannelID, err
+	}
+
+	name := "Notifications"
+	user, err := rt.State.User(userID)
+	if err == nil {
+		name = user.Username + "'s Notifications"
+	}
+
+	thread, err := rt.State.StartThreadWithoutMessage(
+		channelID,
+		api.StartThreadData{
+			Name:                name,
+			AutoArchiveDuration: discord.SevenDaysArchive,
+			Type:                discord.GuildPrivateThread,
+			Invitable:           false,
+		},
+	)
+	if err != nil {
+		return discord.NullChannelID, err
+	}
+
+	err = rt.State.AddThreadMember(thread.ID, userID)
+	if err != nil {
+		rt.State.DeleteChannel(thread.ID, "Unable to add notification user")
+		return discord.NullChannelID, err
+	}
+
+	err = db.Notifications.SetThread(userID, guildID, thread.ID)
+	if err != nil {
+		return discord.NullChannelID, err
+	}
+
+	return thread.ID, nil
+}
+
+func sendWebhookNotification(
+	userID discord.UserID, jumpURL string, data api.SendMessageData) error {
+
+	url, err := db.Notifications.GetWebhook(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errDeliveryUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	client, err := webhook.NewFromURL(url)
+	if err != nil {
+		return err
+	}
+
+	// webhooks not owned by an application cannot send components, so the
+	// jump link is included in the content instead.
+	content := data.Content + "\n" + dctools.Hyperlink("Jump to Message", jumpURL)
+
+	return client.Execute(webhook.ExecuteData{
+		Content:  content,
+		Embeds:   data.Embeds,
+		Username: "Haseul Bot",
+	})
+}
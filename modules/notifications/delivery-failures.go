@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"log"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+)
+
+// deliveryFailureSet tracks which users' delivery methods have stored
+// delivery failures, so failures only need to be reset in the database after
+// a delivery method starts working again.
+type deliveryFailureSet struct {
+	mu      sync.Mutex
+	failing map[notificationTarget]struct{}
+}
+
+// Add marks a user's delivery method as having failed.
+func (s *deliveryFailureSet) Add(target notificationTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failing[target] = struct{}{}
+}
+
+// Remove unmarks a user's delivery method as having failed, returning whether
+// it was marked.
+func (s *deliveryFailureSet) Remove(target notificationTarget) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.failing[target]
+	delete(s.failing, target)
+
+	return ok
+}
+
+func newDeliveryFailureSet() *deliveryFailureSet {
+	set := &deliveryFailureSet{
+		failing: make(map[notificationTarget]struct{}),
+	}
+
+	failures, err := db.Notifications.GetDeliveryFailures()
+	if err != nil {
+		log.Println(err)
+		return set
+	}
+
+	for _, f := range failures {
+		set.Add(notificationTarget{f.UserID, f.Method})
+	}
+
+	return set
+}
+
+// resetDeliveryFailures clears the consecutive delivery failures for a user's
+// delivery method if it has any.
+func resetDeliveryFailures(
+	userID discord.UserID, method notifdb.DeliveryMethod) {
+
+	if !deliveryFailures.Remove(notificationTarget{userID, method}) {
+		return
+	}
+
+	err := db.Notifications.ResetDeliveryFailures(userID, method)
+	if err != nil {
+		log.Println(err)
+	}
+}
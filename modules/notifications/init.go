@@ -1,25 +1,63 @@
 package notifications
 
 import (
+	"time"
+
 	"github.com/twoscott/haseul-bot-2/database"
 	"github.com/twoscott/haseul-bot-2/router"
 )
 
-var db *database.DB
+var (
+	db               *database.DB
+	cooldowns        *cooldownCache
+	deliveryFailures *deliveryFailureSet
+)
 
 func Init(rt *router.Router) {
 	db = database.GetInstance()
+	cooldowns = newCooldownCache(maxNotificationCooldown)
+	go cooldowns.ClearJob(time.Hour)
+	deliveryFailures = newDeliveryFailureSet()
+	go pruneHistoryJob(time.Hour)
 
 	rt.AddMessageHandler(checkKeywords)
+	rt.AddBotMessageHandler(checkKeywords)
+	rt.AddMessageHandler(checkServerAlerts)
+	rt.AddBotMessageHandler(checkServerAlerts)
+	rt.AddButtonListener(handleDeleteAlertedMessage)
 
 	rt.AddCommand(notificationsCommand)
 	notificationsCommand.AddSubCommand(notificationsAddCommand)
 	notificationsCommand.AddSubCommand(notificationsClearCommand)
 	notificationsCommand.AddSubCommand(notificationsDndCommand)
+	notificationsCommand.AddSubCommand(notificationsExportCommand)
+	notificationsCommand.AddSubCommand(notificationsHistoryCommand)
+	notificationsCommand.AddSubCommand(notificationsImportCommand)
 	notificationsCommand.AddSubCommand(notificationsListCommand)
 	notificationsCommand.AddSubCommand(notificationsDeleteCommand)
+	notificationsCommand.AddSubCommand(notificationsRetentionCommand)
+	notificationsCommand.AddSubCommand(notificationsStatsCommand)
 
 	notificationsCommand.AddSubCommandGroup(notificationsChannelCommand)
 	notificationsChannelCommand.AddSubCommand(notificationsChannelMuteCommand)
 	notificationsChannelCommand.AddSubCommand(notificationsChannelUnmuteCommand)
+
+	notificationsCommand.AddSubCommandGroup(notificationsDeliveryCommand)
+	notificationsDeliveryCommand.AddSubCommand(notificationsDeliveryChannelCommand)
+	notificationsDeliveryCommand.AddSubCommand(notificationsDeliveryMethodCommand)
+	notificationsDeliveryCommand.AddSubCommand(notificationsDeliveryStatusCommand)
+	notificationsDeliveryCommand.AddSubCommand(notificationsDeliveryWebhookCommand)
+
+	notificationsCommand.AddSubCommandGroup(notificationsServerCommand)
+	notificationsServerCommand.AddSubCommand(notificationsServerAddCommand)
+	notificationsServerCommand.AddSubCommand(notificationsServerChannelCommand)
+	notificationsServerCommand.AddSubCommand(notificationsServerListCommand)
+	notificationsServerCommand.AddSubCommand(notificationsServerRemoveCommand)
+	notificationsServerCommand.AddSubCommand(notificationsServerRoleCommand)
+
+	notificationsCommand.AddSubCommandGroup(notificationsFilterCommand)
+	notificationsFilterCommand.AddSubCommand(notificationsFilterBotsCommand)
+	notificationsFilterCommand.AddSubCommand(notificationsFilterCooldownCommand)
+	notificationsFilterCommand.AddSubCommand(notificationsFilterRoleCommand)
+	notificationsFilterCommand.AddSubCommand(notificationsFilterUserCommand)
 }
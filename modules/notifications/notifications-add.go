@@ -151,11 +151,10 @@ func addServerNoti(
 	_, err = ctx.State.SendMessage(dmChannel.ID, dmMsg)
 	if dctools.ErrCannotDM(err) {
 		ctx.RespondWarning(
-			"I am unable to DM you. " +
-				"Please open your DMs to server members in your settings.",
-		)
-		db.Notifications.Remove(
-			keyword, ctx.Interaction.SenderID(), ctx.Interaction.GuildID,
+			"Notification was added, but I am unable to DM you. " +
+				"Please open your DMs to server members in your settings, " +
+				"or use `/notifications delivery method` to be notified " +
+				"elsewhere.",
 		)
 		return
 	}
@@ -224,11 +223,10 @@ func addGlobalNoti(
 	_, err = ctx.State.SendMessage(dmChannel.ID, dmMsg)
 	if dctools.ErrCannotDM(err) {
 		ctx.RespondWarning(
-			"I am unable to DM you. " +
-				"Please open your DMs to server members in your settings.",
-		)
-		db.Notifications.RemoveGlobal(
-			keyword, ctx.Interaction.SenderID(),
+			"Notification was added, but I am unable to DM you. " +
+				"Please open your DMs to server members in your settings, " +
+				"or use `/notifications delivery method` to be notified " +
+				"elsewhere.",
 		)
 		return
 	}
@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var notificationsDeliveryChannelCommand = &router.SubCommand{
+	Name: "channel",
+	Description: "Sets the channel private notification threads are " +
+		"created in, or disables them if no channel is given",
+	Handler: &router.CommandHandler{
+		Executor: notificationsDeliveryChannelExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.ChannelOption{
+			OptionName:   "channel",
+			Description:  "The channel to create private notification threads in",
+			ChannelTypes: []discord.ChannelType{discord.GuildText},
+		},
+	},
+}
+
+func notificationsDeliveryChannelExec(ctx router.CommandCtx) {
+	canManage, err := hasSenderPermission(
+		ctx, discord.PermissionManageChannels,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking your permissions.")
+		return
+	}
+	if !canManage {
+		ctx.RespondWarning(
+			"You need the Manage Channels permission to use this command.",
+		)
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("channel").SnowflakeValue()
+	channelID := discord.ChannelID(snowflake)
+	if !channelID.IsValid() {
+		removeThreadChannel(ctx)
+		return
+	}
+
+	channel, cerr := ctx.ParseSendableChannel(channelID)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	var permissions discord.Permissions
+	bot, err := ctx.State.Me()
+	if err == nil {
+		permissions, err = ctx.State.Permissions(channel.ID, bot.ID)
+	}
+	if err != nil {
+		log.Println(err)
+		ctx.RespondErrorf(
+			"Error occurred checking my permissions in %s.",
+			channel.Mention(),
+		)
+		return
+	}
+
+	neededPerms := dctools.PermissionsBitfield(
+		discord.PermissionCreatePrivateThreads,
+		discord.PermissionSendMessagesInThreads,
+	)
+	if !permissions.Has(neededPerms) {
+		ctx.RespondWarningf(
+			"I need permission to create private threads and send "+
+				"messages in threads in %s.",
+			channel.Mention(),
+		)
+		return
+	}
+
+	err = db.Notifications.SetThreadChannel(ctx.Interaction.GuildID, channel.ID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while setting the notification thread channel.",
+		)
+		return
+	}
+
+	ctx.RespondSuccess(
+		"Private notification threads will now be created in " +
+			channel.Mention() + ".",
+	)
+}
+
+func removeThreadChannel(ctx router.CommandCtx) {
+	ok, err := db.Notifications.RemoveThreadChannel(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while removing the notification thread channel.",
+		)
+		return
+	}
+	if !ok {
+		ctx.RespondWarning(
+			"This server does not have a notification thread channel set up.",
+		)
+		return
+	}
+
+	ctx.RespondSuccess("Private notification threads have been disabled.")
+}
@@ -0,0 +1,94 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsDeliveryMethodCommand = &router.SubCommand{
+	Name:        "method",
+	Description: "Sets where a keyword notification is delivered to",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsDeliveryMethodExec,
+		Autocompleter: notificationKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The keyword to set the delivery method of",
+			Required:     true,
+			Autocomplete: true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "method",
+			Description: "Where to deliver notifications for the keyword",
+			Required:    true,
+			Choices: []discord.IntegerChoice{
+				{Name: "DM", Value: int(notifdb.DMDelivery)},
+				{Name: "Private Thread", Value: int(notifdb.ThreadDelivery)},
+				{Name: "Webhook", Value: int(notifdb.WebhookDelivery)},
+			},
+		},
+		&discord.IntegerOption{
+			OptionName:  "scope",
+			Description: "Where the keyword notification is set up",
+			Choices: []discord.IntegerChoice{
+				{Name: "Server", Value: serverScope},
+				{Name: "Global", Value: globalScope},
+			},
+		},
+	},
+}
+
+func notificationsDeliveryMethodExec(ctx router.CommandCtx) {
+	keyword, guildID, cerr := parseFilterTarget(ctx)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	methodOption, _ := ctx.Options.Find("method").IntValue()
+	method := notifdb.DeliveryMethod(methodOption)
+
+	ok, err := db.Notifications.SetDeliveryMethod(
+		keyword, ctx.Interaction.SenderID(), guildID, method,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while updating the notification delivery method.",
+		)
+		return
+	}
+	if !ok {
+		respondKeywordNotFound(ctx, guildID)
+		return
+	}
+
+	resetDeliveryFailures(ctx.Interaction.SenderID(), method)
+
+	switch method {
+	case notifdb.ThreadDelivery:
+		ctx.RespondSuccessf(
+			"Notifications for '%s' will now be delivered to a private "+
+				"thread in the server the keyword was mentioned in, "+
+				"if the server has a notification channel set up.",
+			keyword,
+		)
+	case notifdb.WebhookDelivery:
+		ctx.RespondSuccessf(
+			"Notifications for '%s' will now be delivered to your webhook. "+
+				"Use `/notifications delivery webhook` to set it up.",
+			keyword,
+		)
+	default:
+		ctx.RespondSuccessf(
+			"Notifications for '%s' will now be delivered to your DMs.",
+			keyword,
+		)
+	}
+}
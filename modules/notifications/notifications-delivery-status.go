@@ -0,0 +1,89 @@
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var notificationsDeliveryStatusCommand = &router.SubCommand{
+	Name:        "status",
+	Description: "Shows whether your keyword notifications are being delivered",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsDeliveryStatusExec,
+		Ephemeral: true,
+	},
+}
+
+func notificationsDeliveryStatusExec(ctx router.CommandCtx) {
+	userID := ctx.Interaction.SenderID()
+
+	failures, err := db.Notifications.GetUserDeliveryFailures(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching delivery failures.")
+		return
+	}
+
+	methodFailures := make(map[notifdb.DeliveryMethod]int32, len(failures))
+	for _, f := range failures {
+		methodFailures[f.Method] = f.Failures
+	}
+
+	_, err = db.Notifications.GetWebhook(userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your webhook.")
+		return
+	}
+	webhookSetUp := err == nil
+
+	threadSetUp := false
+	if ctx.Interaction.GuildID.IsValid() {
+		_, err = db.Notifications.GetThreadChannel(ctx.Interaction.GuildID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Println(err)
+			ctx.RespondError(
+				"Error occurred while fetching the notification channel.",
+			)
+			return
+		}
+		threadSetUp = err == nil
+	}
+
+	lines := make([]string, len(notifdb.DeliveryMethods))
+	for i, method := range notifdb.DeliveryMethods {
+		var status string
+		switch {
+		case methodFailures[method] > 0:
+			status = fmt.Sprintf(
+				"⚠️ Failed %d times in a row", methodFailures[method],
+			)
+		case method == notifdb.WebhookDelivery && !webhookSetUp:
+			status = "Not set up"
+		case method == notifdb.ThreadDelivery && !threadSetUp:
+			status = "Not set up in this server"
+		default:
+			status = "✅ Working"
+		}
+
+		lines[i] = fmt.Sprintf("%s: %s", dctools.Bold(method.String()), status)
+	}
+
+	ctx.RespondEmbed(discord.Embed{
+		Title:       "Notification Delivery",
+		Description: strings.Join(lines, "\n"),
+		Color:       dctools.EmbedBackColour,
+		Footer: &discord.EmbedFooter{
+			Text: "Use /notifications delivery method to change where " +
+				"notifications are delivered",
+		},
+	})
+}
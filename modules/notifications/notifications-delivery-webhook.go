@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsDeliveryWebhookCommand = &router.SubCommand{
+	Name: "webhook",
+	Description: "Sets the webhook to deliver notifications to, " +
+		"or removes it if no URL is given",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsDeliveryWebhookExec,
+		Ephemeral: true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:  "url",
+			Description: "The URL of the webhook to deliver notifications to",
+			MaxLength:   option.NewInt(256),
+		},
+	},
+}
+
+func notificationsDeliveryWebhookExec(ctx router.CommandCtx) {
+	url := ctx.Options.Find("url").String()
+	if url == "" {
+		removeDeliveryWebhook(ctx)
+		return
+	}
+
+	client, err := webhook.NewFromURL(url)
+	if err != nil {
+		ctx.RespondWarning("Malformed Discord webhook URL provided.")
+		return
+	}
+
+	_, err = client.Get()
+	if err != nil {
+		ctx.RespondWarning(
+			"Unable to access the webhook. Please check the URL is correct.",
+		)
+		return
+	}
+
+	err = db.Notifications.SetWebhook(ctx.Interaction.SenderID(), url)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting your webhook.")
+		return
+	}
+
+	resetDeliveryFailures(ctx.Interaction.SenderID(), notifdb.WebhookDelivery)
+
+	ctx.RespondSuccess(
+		"Webhook set. Use `/notifications delivery method` to deliver " +
+			"notifications to it.",
+	)
+}
+
+func removeDeliveryWebhook(ctx router.CommandCtx) {
+	ok, err := db.Notifications.RemoveWebhook(ctx.Interaction.SenderID())
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while removing your webhook.")
+		return
+	}
+	if !ok {
+		ctx.RespondWarning("You do not have a webhook set up.")
+		return
+	}
+
+	ctx.RespondSuccess(
+		"Webhook removed. Notifications set to be delivered to a webhook " +
+			"will be delivered elsewhere.",
+	)
+}
@@ -0,0 +1,8 @@
+package notifications
+
+import "github.com/twoscott/haseul-bot-2/router"
+
+var notificationsDeliveryCommand = &router.SubCommandGroup{
+	Name:        "delivery",
+	Description: "Commands pertaining to where keyword notifications are sent",
+}
@@ -0,0 +1,95 @@
+package notifications
+
+import (
+	"bytes"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsExportCommand = &router.SubCommand{
+	Name: "export",
+	Description: "Exports your keyword notifications, mutes and do not " +
+		"disturb status to a file",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsExportExec,
+		Ephemeral: true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "format",
+			Description: "The format of the exported file",
+			Choices: []discord.IntegerChoice{
+				{Name: "JSON", Value: jsonTransferFormat},
+				{Name: "CSV", Value: csvTransferFormat},
+			},
+		},
+	},
+}
+
+func notificationsExportExec(ctx router.CommandCtx) {
+	format, _ := ctx.Options.Find("format").IntValue()
+	userID := ctx.Interaction.SenderID()
+
+	notifications, err := db.Notifications.GetByUser(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your notifications.")
+		return
+	}
+
+	channelMutes, err := db.Notifications.GetChannelMutes(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your muted channels.")
+		return
+	}
+
+	guildMutes, err := db.Notifications.GetGuildMutes(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your muted servers.")
+		return
+	}
+
+	dndOn, err := db.Notifications.HasDnD(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while fetching your do not disturb status.",
+		)
+		return
+	}
+
+	if len(notifications) < 1 && len(channelMutes) < 1 &&
+		len(guildMutes) < 1 && !dndOn {
+
+		ctx.RespondWarning("You do not have any notification settings to export.")
+		return
+	}
+
+	file := notificationsFile{
+		Notifications: make([]exportedNotification, len(notifications)),
+		ChannelMutes:  channelMutes,
+		GuildMutes:    guildMutes,
+		DoNotDisturb:  dndOn,
+	}
+	for i, noti := range notifications {
+		file.Notifications[i] = newExportedNotification(noti)
+	}
+
+	data, err := encodeNotificationsFile(file, format)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while exporting your notifications.")
+		return
+	}
+
+	fileName := "notifications.json"
+	if format == csvTransferFormat {
+		fileName = "notifications.csv"
+	}
+
+	ctx.RespondFile(fileName, bytes.NewReader(data))
+}
@@ -0,0 +1,78 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsFilterBotsCommand = &router.SubCommand{
+	Name: "bots",
+	Description: "Sets whether a keyword notification ignores bots " +
+		"and webhooks",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsFilterBotsExec,
+		Autocompleter: notificationKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The keyword to filter",
+			Required:     true,
+			Autocomplete: true,
+		},
+		&discord.BooleanOption{
+			OptionName:  "ignore",
+			Description: "Whether to ignore messages from bots and webhooks",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "scope",
+			Description: "Where the keyword notification is set up",
+			Choices: []discord.IntegerChoice{
+				{Name: "Server", Value: serverScope},
+				{Name: "Global", Value: globalScope},
+			},
+		},
+	},
+}
+
+func notificationsFilterBotsExec(ctx router.CommandCtx) {
+	keyword, guildID, cerr := parseFilterTarget(ctx)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	ignore, _ := ctx.Options.Find("ignore").BoolValue()
+
+	ok, err := db.Notifications.SetIgnoreBots(
+		keyword, ctx.Interaction.SenderID(), guildID, ignore,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while updating the notification filters.",
+		)
+		return
+	}
+	if !ok {
+		respondKeywordNotFound(ctx, guildID)
+		return
+	}
+
+	if ignore {
+		ctx.RespondSuccessf(
+			"You will no longer be notified when bots or webhooks "+
+				"mention '%s'.",
+			keyword,
+		)
+	} else {
+		ctx.RespondSuccessf(
+			"You will now be notified when bots or webhooks mention '%s'.",
+			keyword,
+		)
+	}
+}
@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const maxNotificationCooldown = 24 * time.Hour
+
+var notificationsFilterCooldownCommand = &router.SubCommand{
+	Name: "cooldown",
+	Description: "Sets how long to wait before being notified of a keyword " +
+		"again in the same channel",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsFilterCooldownExec,
+		Autocompleter: notificationKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The keyword to set a cooldown for",
+			Required:     true,
+			Autocomplete: true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "minutes",
+			Description: "The cooldown in minutes, or 0 to remove the cooldown",
+			Min:         option.NewInt(0),
+			Max:         option.NewInt(int(maxNotificationCooldown.Minutes())),
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "scope",
+			Description: "Where the keyword notification is set up",
+			Choices: []discord.IntegerChoice{
+				{Name: "Server", Value: serverScope},
+				{Name: "Global", Value: globalScope},
+			},
+		},
+	},
+}
+
+func notificationsFilterCooldownExec(ctx router.CommandCtx) {
+	keyword, guildID, cerr := parseFilterTarget(ctx)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	minutes, _ := ctx.Options.Find("minutes").IntValue()
+	cooldown := time.Duration(minutes) * time.Minute
+	if cooldown < 0 || cooldown > maxNotificationCooldown {
+		ctx.RespondWarning("Invalid cooldown provided.")
+		return
+	}
+
+	ok, err := db.Notifications.SetCooldown(
+		keyword, ctx.Interaction.SenderID(), guildID, cooldown,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while updating the notification cooldown.",
+		)
+		return
+	}
+	if !ok {
+		respondKeywordNotFound(ctx, guildID)
+		return
+	}
+
+	if cooldown == 0 {
+		ctx.RespondSuccessf(
+			"You will now be notified every time '%s' is mentioned.",
+			keyword,
+		)
+	} else {
+		ctx.RespondSuccessf(
+			"You will now be notified of '%s' at most once every %s "+
+				"in each channel.",
+			keyword,
+			util.PluraliseWithCount("minute", minutes),
+		)
+	}
+}
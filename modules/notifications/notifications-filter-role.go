@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsFilterRoleCommand = &router.SubCommand{
+	Name: "role",
+	Description: "Sets a role members must have to notify you of " +
+		"a keyword",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsFilterRoleExec,
+		Autocompleter: notificationKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The server keyword to filter",
+			Required:     true,
+			Autocomplete: true,
+		},
+		&discord.RoleOption{
+			OptionName:  "role",
+			Description: "The role required, or leave empty to remove the filter",
+		},
+	},
+}
+
+func notificationsFilterRoleExec(ctx router.CommandCtx) {
+	keyword, guildID, cerr := parseFilterTarget(ctx)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("role").SnowflakeValue()
+	roleID := discord.RoleID(snowflake)
+
+	ok, err := db.Notifications.SetRequiredRole(
+		keyword, ctx.Interaction.SenderID(), guildID, roleID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while updating the notification filters.",
+		)
+		return
+	}
+	if !ok {
+		respondKeywordNotFound(ctx, guildID)
+		return
+	}
+
+	if roleID.IsValid() {
+		ctx.RespondSuccessf(
+			"You will now only be notified of '%s' when mentioned by "+
+				"members with %s.",
+			keyword, roleID.Mention(),
+		)
+	} else {
+		ctx.RespondSuccessf(
+			"You will now be notified of '%s' regardless of roles.",
+			keyword,
+		)
+	}
+}
@@ -0,0 +1,185 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+const filteredUserLimit = 25
+
+const (
+	onlyUserFilter = iota
+	ignoreUserFilter
+	removeUserFilter
+)
+
+var notificationsFilterUserCommand = &router.SubCommand{
+	Name: "user",
+	Description: "Sets whether a user's mentions of a keyword " +
+		"notify you",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsFilterUserExec,
+		Autocompleter: notificationKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The keyword to filter",
+			Required:     true,
+			Autocomplete: true,
+		},
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The user to filter",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "filter",
+			Description: "How to filter the user's messages",
+			Choices: []discord.IntegerChoice{
+				{Name: "Only From", Value: onlyUserFilter},
+				{Name: "Ignore", Value: ignoreUserFilter},
+				{Name: "Remove", Value: removeUserFilter},
+			},
+			Required: true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "scope",
+			Description: "Where the keyword notification is set up",
+			Choices: []discord.IntegerChoice{
+				{Name: "Server", Value: serverScope},
+				{Name: "Global", Value: globalScope},
+			},
+		},
+	},
+}
+
+func notificationsFilterUserExec(ctx router.CommandCtx) {
+	keyword, guildID, cerr := parseFilterTarget(ctx)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("user").SnowflakeValue()
+	targetID := discord.UserID(snowflake)
+	if !targetID.IsValid() {
+		ctx.RespondWarning("Invalid user provided.")
+		return
+	}
+
+	filter, _ := ctx.Options.Find("filter").IntValue()
+
+	switch filter {
+	case onlyUserFilter:
+		addFilteredUser(ctx, keyword, guildID, targetID, true)
+	case ignoreUserFilter:
+		addFilteredUser(ctx, keyword, guildID, targetID, false)
+	case removeUserFilter:
+		removeFilteredUser(ctx, keyword, guildID, targetID)
+	default:
+		ctx.RespondError("Invalid user filter selected.")
+	}
+}
+
+func addFilteredUser(
+	ctx router.CommandCtx,
+	keyword string,
+	guildID discord.GuildID,
+	targetID discord.UserID,
+	only bool) {
+
+	userID := ctx.Interaction.SenderID()
+	if targetID == userID {
+		ctx.RespondWarning("You cannot filter your own messages.")
+		return
+	}
+
+	noti, err := db.Notifications.GetByKeyword(keyword, userID, guildID)
+	if err != nil {
+		respondKeywordNotFound(ctx, guildID)
+		return
+	}
+
+	filtered := noti.IgnoredUsers()
+	if only {
+		filtered = noti.OnlyUsers()
+	}
+	if len(filtered) >= filteredUserLimit {
+		ctx.RespondWarningf(
+			"You cannot filter more than %d users for a single keyword.",
+			filteredUserLimit,
+		)
+		return
+	}
+
+	var ok bool
+	if only {
+		ok, err = db.Notifications.AddOnlyUser(
+			keyword, userID, guildID, targetID,
+		)
+	} else {
+		ok, err = db.Notifications.AddIgnoredUser(
+			keyword, userID, guildID, targetID,
+		)
+	}
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while updating the notification filters.",
+		)
+		return
+	}
+	if !ok {
+		respondKeywordNotFound(ctx, guildID)
+		return
+	}
+
+	var content string
+	if only {
+		content = fmt.Sprintf(
+			"You will now only be notified of '%s' when mentioned by %s "+
+				"or any other users you have added.",
+			keyword, targetID.Mention(),
+		)
+	} else {
+		content = fmt.Sprintf(
+			"You will no longer be notified when %s mentions '%s'.",
+			targetID.Mention(), keyword,
+		)
+	}
+
+	ctx.RespondSuccess(content)
+}
+
+func removeFilteredUser(
+	ctx router.CommandCtx,
+	keyword string,
+	guildID discord.GuildID,
+	targetID discord.UserID) {
+
+	ok, err := db.Notifications.RemoveFilteredUser(
+		keyword, ctx.Interaction.SenderID(), guildID, targetID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while updating the notification filters.",
+		)
+		return
+	}
+	if !ok {
+		ctx.RespondWarningf(
+			"%s is not filtered for this keyword.", targetID.Mention(),
+		)
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"%s is no longer filtered for '%s'.", targetID.Mention(), keyword,
+	)
+}
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsFilterCommand = &router.SubCommandGroup{
+	Name:        "filter",
+	Description: "Commands pertaining to filtering keyword notifications",
+}
+
+// parseFilterTarget returns the keyword and guild ID of the notification
+// targeted by a filter command. A guild ID of 0 refers to a global
+// notification.
+func parseFilterTarget(
+	ctx router.CommandCtx) (string, discord.GuildID, router.CmdResponse) {
+
+	rawKeyword := ctx.Options.Find("keyword").String()
+	keyword := strings.ToLower(rawKeyword)
+
+	scope, _ := ctx.Options.Find("scope").IntValue()
+
+	switch scope {
+	case serverScope:
+		if !ctx.Interaction.GuildID.IsValid() {
+			return "", 0, router.Warning(
+				"Server notifications can only be changed from within a " +
+					"server.",
+			)
+		}
+		return keyword, ctx.Interaction.GuildID, nil
+	case globalScope:
+		return keyword, 0, nil
+	default:
+		return "", 0, router.Error(
+			"Invalid notification scope selected.",
+		)
+	}
+}
+
+func respondKeywordNotFound(ctx router.CommandCtx, guildID discord.GuildID) {
+	if guildID.IsValid() {
+		ctx.RespondWarning(
+			"This keyword is not in your server notifications list.",
+		)
+	} else {
+		ctx.RespondWarning(
+			"This keyword is not in your global notifications list.",
+		)
+	}
+}
@@ -0,0 +1,97 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var notificationsHistoryCommand = &router.SubCommand{
+	Name:        "history",
+	Description: "Lists messages that mentioned your keywords",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsHistoryExec,
+		Autocompleter: notificationKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The keyword to list mentions of",
+			Autocomplete: true,
+		},
+	},
+}
+
+func notificationsHistoryExec(ctx router.CommandCtx) {
+	rawKeyword := ctx.Options.Find("keyword").String()
+	keyword := strings.ToLower(rawKeyword)
+
+	var (
+		entries []notifdb.HistoryEntry
+		err     error
+	)
+	if keyword == "" {
+		entries, err = db.Notifications.GetHistory(ctx.Interaction.SenderID())
+	} else {
+		entries, err = db.Notifications.GetKeywordHistory(
+			ctx.Interaction.SenderID(), keyword,
+		)
+	}
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while fetching your notification history.",
+		)
+		return
+	}
+	if len(entries) < 1 {
+		ctx.RespondWarning("You have no notification history.")
+		return
+	}
+
+	historyList := make([]string, len(entries))
+	for i, entry := range entries {
+		link := dctools.MessageLink(
+			entry.GuildID, entry.ChannelID, entry.MessageID,
+		)
+		historyList[i] = fmt.Sprintf(
+			"- %s %s in %s - %s",
+			dctools.TimestampStyled(entry.Time, dctools.RelativeTime),
+			dctools.Bold(dctools.EscapeMarkdown(entry.Keyword)),
+			entry.ChannelID.Mention(),
+			dctools.Hyperlink("Jump", link),
+		)
+	}
+
+	descriptionPages := util.PagedLines(historyList, 2048, 10)
+	pages := make([]router.MessagePage, len(descriptionPages))
+	footer := util.PluraliseWithCount("Match", int64(len(entries)))
+
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Title:       "Notification History",
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(
+							pageID,
+							footer,
+						),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
@@ -0,0 +1,364 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/lib/pq"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const (
+	maxImportFileSize = 1 << 20
+	keywordMaxLength  = 128
+)
+
+const (
+	importIntoCurrentGuild = iota
+	importIntoOriginalGuild
+)
+
+var notificationsImportCommand = &router.SubCommand{
+	Name: "import",
+	Description: "Imports keyword notifications, mutes and do not disturb " +
+		"status from an exported file",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsImportExec,
+		Ephemeral: true,
+		Defer:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.AttachmentOption{
+			OptionName:  "file",
+			Description: "The JSON or CSV file from /notifications export",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "server-keywords",
+			Description: "Which server to import server notifications into",
+			Choices: []discord.IntegerChoice{
+				{Name: "This Server", Value: importIntoCurrentGuild},
+				{Name: "Original Server", Value: importIntoOriginalGuild},
+			},
+		},
+	},
+}
+
+// notificationImporter imports notifications for a user, keeping track of
+// how many notifications each scope has to enforce the notification limit.
+type notificationImporter struct {
+	userID      discord.UserID
+	guildID     discord.GuildID
+	retarget    bool
+	scopeCounts map[discord.GuildID]int
+}
+
+func notificationsImportExec(ctx router.CommandCtx) {
+	// server notifications can only be moved into the current server when
+	// importing from a server, so imports from DMs keep their original server.
+	target, err := ctx.Options.Find("server-keywords").IntValue()
+	chosen := err == nil
+	retarget := !chosen || target == importIntoCurrentGuild
+	if !ctx.Interaction.GuildID.IsValid() {
+		if chosen && retarget {
+			ctx.RespondWarning(
+				"Server notifications can only be imported into this " +
+					"server from within a server.",
+			)
+			return
+		}
+		retarget = false
+	}
+
+	snowflake, _ := ctx.Options.Find("file").SnowflakeValue()
+	attachmentID := discord.AttachmentID(snowflake)
+	attachment, ok := ctx.Command.Resolved.Attachments[attachmentID]
+	if !ok {
+		ctx.RespondWarning("Please provide a file to import.")
+		return
+	}
+	if attachment.Size > maxImportFileSize {
+		ctx.RespondWarning("Import files must be smaller than 1 MB.")
+		return
+	}
+
+	data, err := dctools.DownloadAttachment(attachment)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred downloading attachment.")
+		return
+	}
+
+	file, invalidRows, err := decodeNotificationsFile(attachment.Filename, data)
+	if err != nil {
+		ctx.RespondWarning(
+			"Unable to read the file. Please provide a JSON or CSV file " +
+				"from `/notifications export`.",
+		)
+		return
+	}
+
+	importer := notificationImporter{
+		userID:      ctx.Interaction.SenderID(),
+		guildID:     ctx.Interaction.GuildID,
+		retarget:    retarget,
+		scopeCounts: make(map[discord.GuildID]int),
+	}
+
+	results := make([]string, 0, len(file.Notifications)+len(invalidRows))
+	for _, row := range invalidRows {
+		results = append(results, router.Error(row).String())
+	}
+
+	imported := 0
+	for _, noti := range file.Notifications {
+		res := importer.importNotification(noti)
+		if _, ok := res.(router.CmdSuccess); ok {
+			imported++
+		}
+		results = append(results, res.String())
+	}
+
+	results = append(results, importer.importMutes(*file)...)
+
+	// do not disturb is only turned on by imports, so that importing an
+	// older export doesn't turn off a user's current do not disturb status.
+	if file.DoNotDisturb {
+		err = db.Notifications.SetDnD(importer.userID, true)
+		if err != nil {
+			log.Println(err)
+			results = append(results, router.Error(
+				"Error occurred while turning on do not disturb.",
+			).String())
+		}
+	}
+
+	respondImportResults(ctx, results, imported)
+}
+
+func (i *notificationImporter) importNotification(
+	exported exportedNotification) router.CmdResponse {
+
+	keyword := strings.ToLower(exported.Keyword)
+	entry := dctools.Bold(dctools.EscapeMarkdown(keyword))
+
+	if keyword == "" {
+		return router.Error("Missing keyword.")
+	}
+	if runes := []rune(keyword); len(runes) > keywordMaxLength {
+		return router.Errorf(
+			"%s…: keywords must be less than %d characters in length.",
+			dctools.Bold(dctools.EscapeMarkdown(string(runes[:32]))),
+			keywordMaxLength,
+		)
+	}
+
+	notiType, ok := parseNotificationType(exported.Type)
+	if !ok {
+		return router.Errorf(
+			"%s: invalid notification type %q.", entry, exported.Type,
+		)
+	}
+
+	delivery, ok := parseDeliveryMethod(exported.Delivery)
+	if !ok {
+		return router.Errorf(
+			"%s: invalid delivery method %q.", entry, exported.Delivery,
+		)
+	}
+
+	cooldown := int64(exported.CooldownSeconds)
+	if cooldown < 0 || cooldown > int64(maxNotificationCooldown.Seconds()) {
+		return router.Errorf("%s: invalid cooldown.", entry)
+	}
+
+	if len(exported.OnlyUserIDs) > filteredUserLimit ||
+		len(exported.IgnoredUserIDs) > filteredUserLimit {
+
+		return router.Errorf(
+			"%s: filters cannot have more than %d users.",
+			entry, filteredUserLimit,
+		)
+	}
+
+	// missing or null IDs are stored as 0, which refers to a global
+	// notification or no required role.
+	guildID := exported.GuildID
+	if !guildID.IsValid() {
+		guildID = 0
+	}
+	roleID := exported.RequiredRoleID
+	if !roleID.IsValid() {
+		roleID = 0
+	}
+	var note string
+	if guildID.IsValid() && i.retarget && i.guildID.IsValid() &&
+		guildID != i.guildID {
+
+		guildID = i.guildID
+		if roleID.IsValid() {
+			roleID = discord.NullRoleID
+			note = " Required role was removed as it belongs to another server."
+		}
+	}
+
+	count, err := i.scopeCount(guildID)
+	if err != nil {
+		log.Println(err)
+		return router.Errorf(
+			"%s: error occurred checking your notifications.", entry,
+		)
+	}
+	if count >= notificationLimit {
+		return router.Errorf(
+			"%s: you cannot have more than %d notifications in this scope.",
+			entry, notificationLimit,
+		)
+	}
+
+	noti := notifdb.Notification{
+		Keyword:  keyword,
+		UserID:   i.userID,
+		Type:     notiType,
+		GuildID:  guildID,
+		Delivery: delivery,
+		Filters: notifdb.Filters{
+			CooldownSeconds: int32(cooldown),
+			OnlyUserIDs:     userIDsToInt64s(exported.OnlyUserIDs),
+			IgnoredUserIDs:  userIDsToInt64s(exported.IgnoredUserIDs),
+			IgnoreBots:      !exported.IncludeBots,
+			RequiredRoleID:  roleID,
+		},
+	}
+
+	ok, err = db.Notifications.Import(noti)
+	if err != nil {
+		log.Println(err)
+		return router.Errorf("%s: error occurred adding the keyword.", entry)
+	}
+	if !ok {
+		return router.Warningf(
+			"%s: you are already notified of this keyword.", entry,
+		)
+	}
+
+	i.scopeCounts[guildID]++
+
+	scope := "global"
+	if guildID.IsValid() {
+		scope = "server"
+	}
+
+	return router.Successf("%s: added as a %s notification.%s", entry, scope, note)
+}
+
+func (i *notificationImporter) scopeCount(
+	guildID discord.GuildID) (int, error) {
+
+	if count, ok := i.scopeCounts[guildID]; ok {
+		return count, nil
+	}
+
+	var (
+		notifications []notifdb.Notification
+		err           error
+	)
+	if guildID.IsValid() {
+		notifications, err = db.Notifications.GetByGuildUser(i.userID, guildID)
+	} else {
+		notifications, err = db.Notifications.GetByGlobalUser(i.userID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	i.scopeCounts[guildID] = len(notifications)
+	return len(notifications), nil
+}
+
+func (i *notificationImporter) importMutes(file notificationsFile) []string {
+	results := make([]string, 0, len(file.ChannelMutes)+len(file.GuildMutes))
+
+	for _, channelID := range file.ChannelMutes {
+		_, err := db.Notifications.MuteChannel(i.userID, channelID)
+		if err != nil {
+			log.Println(err)
+			results = append(results, router.Errorf(
+				"Channel `%s`: error occurred muting the channel.", channelID,
+			).String())
+			continue
+		}
+
+		results = append(results, router.Successf(
+			"Channel `%s`: muted.", channelID,
+		).String())
+	}
+
+	for _, guildID := range file.GuildMutes {
+		_, err := db.Notifications.MuteGuild(i.userID, guildID)
+		if err != nil {
+			log.Println(err)
+			results = append(results, router.Errorf(
+				"Server `%s`: error occurred muting the server.", guildID,
+			).String())
+			continue
+		}
+
+		results = append(results, router.Successf(
+			"Server `%s`: muted.", guildID,
+		).String())
+	}
+
+	return results
+}
+
+func respondImportResults(
+	ctx router.CommandCtx, results []string, imported int) {
+
+	if len(results) < 1 {
+		ctx.RespondWarning("The file does not contain any notification settings.")
+		return
+	}
+
+	descriptionPages := util.PagedLines(results, 2048, 15)
+	pages := make([]router.MessagePage, len(descriptionPages))
+	footer := util.PluraliseWithCount("Notification", int64(imported)) +
+		" Imported"
+
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Title:       "Notification Import",
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(
+							pageID,
+							footer,
+						),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
+
+func userIDsToInt64s(userIDs []discord.UserID) pq.Int64Array {
+	ids := make(pq.Int64Array, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID.IsValid() {
+			ids = append(ids, int64(userID))
+		}
+	}
+
+	return ids
+}
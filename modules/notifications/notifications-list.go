@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"log"
 	"slices"
+	"strings"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
 	"github.com/twoscott/haseul-bot-2/router"
 	"github.com/twoscott/haseul-bot-2/utils/dctools"
 	"github.com/twoscott/haseul-bot-2/utils/util"
@@ -54,6 +56,12 @@ func notificationsListExec(ctx router.CommandCtx) {
 			noti.Type,
 			scope,
 		)
+		if noti.IsFiltered() {
+			entry += "\n  - " + filterSummary(noti.Filters)
+		}
+		if noti.Delivery != notifdb.DMDelivery {
+			entry += "\n  - delivered by " + noti.Delivery.String()
+		}
 		notiList = append(notiList, entry)
 	}
 
@@ -84,3 +92,31 @@ func notificationsListExec(ctx router.CommandCtx) {
 
 	ctx.RespondPaging(pages)
 }
+
+func filterSummary(filters notifdb.Filters) string {
+	summary := make([]string, 0, 5)
+
+	if filters.CooldownSeconds > 0 {
+		minutes := int64(filters.Cooldown().Minutes())
+		cooldown := util.PluraliseWithCount("minute", minutes)
+		summary = append(summary, cooldown+" cooldown")
+	}
+	if len(filters.OnlyUserIDs) > 0 {
+		users := util.PluraliseWithCount("user", int64(len(filters.OnlyUserIDs)))
+		summary = append(summary, "only from "+users)
+	}
+	if len(filters.IgnoredUserIDs) > 0 {
+		users := util.PluraliseWithCount(
+			"user", int64(len(filters.IgnoredUserIDs)),
+		)
+		summary = append(summary, "ignoring "+users)
+	}
+	if !filters.IgnoreBots {
+		summary = append(summary, "including bots")
+	}
+	if filters.RequiredRoleID.IsValid() {
+		summary = append(summary, "requires "+filters.RequiredRoleID.Mention())
+	}
+
+	return strings.Join(summary, ", ")
+}
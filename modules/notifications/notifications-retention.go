@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const maxHistoryRetentionDays = 365
+
+var notificationsRetentionCommand = &router.SubCommand{
+	Name: "retention",
+	Description: "Sets how many days your notification history is kept for, " +
+		"or 0 to turn it off",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsRetentionExec,
+		Ephemeral: true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "days",
+			Description: "The number of days to keep notification history for",
+			Min:         option.NewInt(0),
+			Max:         option.NewInt(maxHistoryRetentionDays),
+			Required:    true,
+		},
+	},
+}
+
+func notificationsRetentionExec(ctx router.CommandCtx) {
+	days, _ := ctx.Options.Find("days").IntValue()
+	if days < 0 || days > maxHistoryRetentionDays {
+		ctx.RespondWarningf(
+			"History retention must be between 0 and %d days.",
+			maxHistoryRetentionDays,
+		)
+		return
+	}
+
+	err := db.Notifications.SetHistoryRetention(
+		ctx.Interaction.SenderID(), int32(days),
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while setting your history retention.",
+		)
+		return
+	}
+
+	if days == 0 {
+		ctx.RespondSuccess(
+			"Notification history turned off. " +
+				"Your existing history will be deleted shortly.",
+		)
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"Your notification history will now be kept for %s.",
+		util.PluraliseWithCount("day", days),
+	)
+}
@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsServerAddCommand = &router.SubCommand{
+	Name:        "add",
+	Description: "Adds a server keyword alert for moderators",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsServerAddExec,
+		Ephemeral: true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:  "keyword",
+			Description: "The keyword to alert moderators of",
+			MaxLength:   option.NewInt(keywordMaxLength),
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "type",
+			Description: "How to match the keyword",
+			Choices: []discord.IntegerChoice{
+				{Name: "Normal", Value: int(notifdb.NormalNotification)},
+				{Name: "Strict", Value: int(notifdb.StrictNotification)},
+				{Name: "Lenient", Value: int(notifdb.LenientNotification)},
+			},
+		},
+	},
+}
+
+func notificationsServerAddExec(ctx router.CommandCtx) {
+	if !checkServerAlertManager(ctx) {
+		return
+	}
+
+	rawKeyword := ctx.Options.Find("keyword").String()
+	keyword := strings.ToLower(rawKeyword)
+	if keyword == "" {
+		ctx.RespondWarning("Please provide a keyword to alert moderators of.")
+		return
+	}
+	if len([]rune(keyword)) > keywordMaxLength {
+		ctx.RespondWarningf(
+			"Keywords must be less than %d characters in length.",
+			keywordMaxLength,
+		)
+		return
+	}
+
+	typeOption, _ := ctx.Options.Find("type").IntValue()
+	alertType := notifdb.NotificationType(typeOption)
+
+	alerts, err := db.Notifications.GetServerAlerts(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking server alerts.")
+		return
+	}
+	if len(alerts) >= serverAlertLimit {
+		ctx.RespondWarningf(
+			"You cannot have more than %d server keyword alerts.",
+			serverAlertLimit,
+		)
+		return
+	}
+
+	ok, err := db.Notifications.AddServerAlert(
+		ctx.Interaction.GuildID, keyword, alertType,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while adding keyword to the database.",
+		)
+		return
+	}
+	if !ok {
+		ctx.RespondWarning("This keyword is already a server alert.")
+		return
+	}
+
+	config, err := db.Notifications.GetServerAlertConfig(
+		ctx.Interaction.GuildID,
+	)
+	if err != nil || !config.ChannelID.IsValid() {
+		ctx.RespondWarning(
+			"Server alert was added, but no alert channel is set up. " +
+				"Use `/notifications server channel` to set one.",
+		)
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"Moderators will now be alerted in %s when '%s' is mentioned.",
+		config.ChannelID.Mention(), keyword,
+	)
+}
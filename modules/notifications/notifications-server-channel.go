@@ -0,0 +1,67 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var notificationsServerChannelCommand = &router.SubCommand{
+	Name: "channel",
+	Description: "Sets the channel server keyword alerts are posted to, " +
+		"or turns alerts off if no channel is given",
+	Handler: &router.CommandHandler{
+		Executor: notificationsServerChannelExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.ChannelOption{
+			OptionName:   "channel",
+			Description:  "The channel to post server keyword alerts to",
+			ChannelTypes: dctools.TextChannelTypes(),
+		},
+	},
+}
+
+func notificationsServerChannelExec(ctx router.CommandCtx) {
+	if !checkServerAlertAdmin(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("channel").SnowflakeValue()
+	channelID := discord.ChannelID(snowflake)
+	if !channelID.IsValid() {
+		err := db.Notifications.SetServerAlertChannel(
+			ctx.Interaction.GuildID, discord.NullChannelID,
+		)
+		if err != nil {
+			log.Println(err)
+			ctx.RespondError("Error occurred while turning off server alerts.")
+			return
+		}
+
+		ctx.RespondSuccess("Server keyword alerts have been turned off.")
+		return
+	}
+
+	channel, cerr := ctx.ParseSendableChannel(channelID)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	err := db.Notifications.SetServerAlertChannel(
+		ctx.Interaction.GuildID, channel.ID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting the alert channel.")
+		return
+	}
+
+	ctx.RespondSuccess(
+		"Server keyword alerts will now be posted to " +
+			channel.Mention() + ".",
+	)
+}
@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var notificationsServerListCommand = &router.SubCommand{
+	Name:        "list",
+	Description: "Lists all server keyword alerts",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsServerListExec,
+		Ephemeral: true,
+	},
+}
+
+func notificationsServerListExec(ctx router.CommandCtx) {
+	if !checkServerAlertManager(ctx) {
+		return
+	}
+
+	alerts, err := db.Notifications.GetServerAlerts(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching server alerts.")
+		return
+	}
+	if len(alerts) < 1 {
+		ctx.RespondWarning("This server has no keyword alerts set up.")
+		return
+	}
+
+	alertList := make([]string, len(alerts))
+	for i, alert := range alerts {
+		alertList[i] = fmt.Sprintf(
+			"- %s - %s",
+			dctools.Bold(dctools.EscapeMarkdown(alert.Keyword)),
+			alert.Type,
+		)
+	}
+
+	footer := util.PluraliseWithCount("Alert", int64(len(alerts)))
+	config, err := db.Notifications.GetServerAlertConfig(
+		ctx.Interaction.GuildID,
+	)
+	if err == nil && config.ChannelID.IsValid() {
+		channel, err := ctx.State.Channel(config.ChannelID)
+		if err == nil {
+			footer = dctools.SeparateEmbedFooter(
+				footer, "Posted to "+dctools.GetChannelString(*channel),
+			)
+		}
+	}
+
+	descriptionPages := util.PagedLines(alertList, 2048, 20)
+	pages := make([]router.MessagePage, len(descriptionPages))
+
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Title:       "Server Keyword Alerts",
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(
+							pageID,
+							footer,
+						),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
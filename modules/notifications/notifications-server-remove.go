@@ -0,0 +1,89 @@
+package notifications
+
+import (
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var notificationsServerRemoveCommand = &router.SubCommand{
+	Name:        "remove",
+	Description: "Removes a server keyword alert",
+	Handler: &router.CommandHandler{
+		Executor:      notificationsServerRemoveExec,
+		Autocompleter: serverAlertKeywordCompleter,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "keyword",
+			Description:  "The keyword to stop alerting moderators of",
+			Required:     true,
+			Autocomplete: true,
+		},
+	},
+}
+
+func notificationsServerRemoveExec(ctx router.CommandCtx) {
+	if !checkServerAlertManager(ctx) {
+		return
+	}
+
+	rawKeyword := ctx.Options.Find("keyword").String()
+	keyword := strings.ToLower(rawKeyword)
+
+	ok, err := db.Notifications.RemoveServerAlert(
+		ctx.Interaction.GuildID, keyword,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while removing keyword from the database.",
+		)
+		return
+	}
+	if !ok {
+		ctx.RespondWarning("This keyword is not a server alert.")
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"Moderators will no longer be alerted when '%s' is mentioned.",
+		keyword,
+	)
+}
+
+func serverAlertKeywordCompleter(ctx router.AutocompleteCtx) {
+	keyword := ctx.Options.Find("keyword").String()
+
+	alerts, err := db.Notifications.GetServerAlerts(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondChoices(nil)
+		return
+	}
+	if len(alerts) == 0 {
+		ctx.RespondChoices(nil)
+		return
+	}
+
+	keywords := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		keywords = append(keywords, a.Keyword)
+	}
+
+	var choices api.AutocompleteStringChoices
+	if keyword == "" {
+		choices = dctools.MakeStringChoices(keywords)
+	} else {
+		matches := util.SearchSort(keywords, keyword)
+		choices = dctools.MakeStringChoices(matches)
+	}
+
+	ctx.RespondChoices(choices)
+}
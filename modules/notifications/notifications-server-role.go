@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var notificationsServerRoleCommand = &router.SubCommand{
+	Name: "role",
+	Description: "Sets the role that can manage server keyword alerts, " +
+		"or removes it if no role is given",
+	Handler: &router.CommandHandler{
+		Executor: notificationsServerRoleExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.RoleOption{
+			OptionName:  "role",
+			Description: "The role that can manage server keyword alerts",
+		},
+	},
+}
+
+func notificationsServerRoleExec(ctx router.CommandCtx) {
+	if !checkServerAlertAdmin(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("role").SnowflakeValue()
+	roleID := discord.RoleID(snowflake)
+
+	err := db.Notifications.SetServerAlertManagerRole(
+		ctx.Interaction.GuildID, roleID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting the alert manager role.")
+		return
+	}
+
+	if !roleID.IsValid() {
+		ctx.RespondSuccess(
+			"Only members with the Manage Server permission can now " +
+				"manage server keyword alerts.",
+		)
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"Members with %s can now manage server keyword alerts.",
+		roleID.Mention(),
+	)
+}
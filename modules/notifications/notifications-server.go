@@ -0,0 +1,90 @@
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+const serverAlertLimit = 100
+
+var notificationsServerCommand = &router.SubCommandGroup{
+	Name:        "server",
+	Description: "Commands pertaining to server keyword alerts for moderators",
+}
+
+// hasSenderPermission returns whether the user who invoked the command has the
+// permission, or the Administrator permission, in the current channel.
+func hasSenderPermission(
+	ctx router.CommandCtx, permission discord.Permissions) (bool, error) {
+
+	permissions, err := ctx.State.Permissions(
+		ctx.Interaction.ChannelID, ctx.Interaction.SenderID(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return dctools.HasAnyPermOrAdmin(permissions, permission), nil
+}
+
+// checkServerAlertManager responds with a warning and returns false if the
+// user is unable to manage server keyword alerts. Members with the Manage
+// Server permission, or the server's alert manager role, can manage alerts.
+func checkServerAlertManager(ctx router.CommandCtx) bool {
+	canManage, err := hasSenderPermission(ctx, discord.PermissionManageGuild)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking your permissions.")
+		return false
+	}
+	if canManage {
+		return true
+	}
+
+	config, err := db.Notifications.GetServerAlertConfig(
+		ctx.Interaction.GuildID,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking your permissions.")
+		return false
+	}
+
+	member := ctx.Interaction.Member
+	if config.ManagerRoleID.IsValid() && member != nil &&
+		slices.Contains(member.RoleIDs, config.ManagerRoleID) {
+
+		return true
+	}
+
+	ctx.RespondWarning(
+		"You need the Manage Server permission or the alert manager role " +
+			"to manage server keyword alerts.",
+	)
+	return false
+}
+
+// checkServerAlertAdmin responds with a warning and returns false if the user
+// is unable to change server keyword alert settings.
+func checkServerAlertAdmin(ctx router.CommandCtx) bool {
+	isAdmin, err := hasSenderPermission(ctx, discord.PermissionManageGuild)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking your permissions.")
+		return false
+	}
+	if !isAdmin {
+		ctx.RespondWarning(
+			"You need the Manage Server permission to use this command.",
+		)
+		return false
+	}
+
+	return true
+}
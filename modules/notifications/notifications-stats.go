@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const statsLimit = 10
+
+var notificationsStatsCommand = &router.SubCommand{
+	Name:        "stats",
+	Description: "Shows your most mentioned keywords and busiest channels",
+	Handler: &router.CommandHandler{
+		Executor:  notificationsStatsExec,
+		Ephemeral: true,
+	},
+}
+
+func notificationsStatsExec(ctx router.CommandCtx) {
+	userID := ctx.Interaction.SenderID()
+
+	total, err := db.Notifications.GetHistoryCount(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while fetching your notification history.",
+		)
+		return
+	}
+	if total < 1 {
+		ctx.RespondWarning("You have no notification history.")
+		return
+	}
+
+	keywords, err := db.Notifications.GetTopKeywords(userID, statsLimit)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your top keywords.")
+		return
+	}
+
+	channels, err := db.Notifications.GetTopChannels(userID, statsLimit)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your top channels.")
+		return
+	}
+
+	retention, err := db.Notifications.GetHistoryRetention(userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while fetching your history retention.",
+		)
+		return
+	}
+
+	keywordList := make([]string, len(keywords))
+	for i, k := range keywords {
+		keywordList[i] = fmt.Sprintf(
+			"%d. %s - %s",
+			i+1,
+			dctools.Bold(dctools.EscapeMarkdown(k.Keyword)),
+			util.PluraliseWithCount("match", k.Count),
+		)
+	}
+
+	channelList := make([]string, len(channels))
+	for i, c := range channels {
+		channelList[i] = fmt.Sprintf(
+			"%d. %s - %s",
+			i+1,
+			c.ChannelID.Mention(),
+			util.PluraliseWithCount("match", c.Count),
+		)
+	}
+
+	footer := util.PluraliseWithCount("Match", total)
+	if retention > 0 {
+		footer += " in the last " +
+			util.PluraliseWithCount("Day", int64(retention))
+	}
+
+	ctx.RespondEmbed(discord.Embed{
+		Title: "Notification Stats",
+		Color: dctools.EmbedBackColour,
+		Fields: []discord.EmbedField{
+			{
+				Name:   "Top Keywords",
+				Value:  strings.Join(keywordList, "\n"),
+				Inline: true,
+			},
+			{
+				Name:   "Busiest Channels",
+				Value:  strings.Join(channelList, "\n"),
+				Inline: true,
+			},
+		},
+		Footer: &discord.EmbedFooter{Text: footer},
+	})
+}
@@ -0,0 +1,394 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/notifdb"
+)
+
+const (
+	jsonTransferFormat = iota
+	csvTransferFormat
+)
+
+const (
+	csvNotificationKind = "notification"
+	csvChannelMuteKind  = "channel_mute"
+	csvGuildMuteKind    = "guild_mute"
+	csvDnDKind          = "do_not_disturb"
+)
+
+var csvHeader = []string{
+	"kind",
+	"keyword",
+	"type",
+	"guild_id",
+	"delivery",
+	"cooldown_seconds",
+	"include_bots",
+	"required_role_id",
+	"only_user_ids",
+	"ignored_user_ids",
+	"channel_id",
+}
+
+// notificationsFile represents a user's exported notifications, mutes and
+// do not disturb status.
+type notificationsFile struct {
+	Notifications []exportedNotification `json:"notifications"`
+	ChannelMutes  []discord.ChannelID    `json:"channelMutes"`
+	GuildMutes    []discord.GuildID      `json:"guildMutes"`
+	DoNotDisturb  bool                   `json:"doNotDisturb"`
+}
+
+// exportedNotification represents a notification in an exported file. Types
+// and delivery methods are stored by name so files remain readable.
+type exportedNotification struct {
+	Keyword         string           `json:"keyword"`
+	Type            string           `json:"type"`
+	GuildID         discord.GuildID  `json:"guildID,omitempty"`
+	Delivery        string           `json:"delivery"`
+	CooldownSeconds int32            `json:"cooldownSeconds"`
+	IncludeBots     bool             `json:"includeBots"`
+	RequiredRoleID  discord.RoleID   `json:"requiredRoleID,omitempty"`
+	OnlyUserIDs     []discord.UserID `json:"onlyUserIDs"`
+	IgnoredUserIDs  []discord.UserID `json:"ignoredUserIDs"`
+}
+
+func newExportedNotification(noti notifdb.Notification) exportedNotification {
+	return exportedNotification{
+		Keyword:         noti.Keyword,
+		Type:            noti.Type.String(),
+		GuildID:         noti.GuildID,
+		Delivery:        noti.Delivery.String(),
+		CooldownSeconds: noti.CooldownSeconds,
+		IncludeBots:     !noti.IgnoreBots,
+		RequiredRoleID:  noti.RequiredRoleID,
+		OnlyUserIDs:     noti.OnlyUsers(),
+		IgnoredUserIDs:  noti.IgnoredUsers(),
+	}
+}
+
+func parseNotificationType(name string) (notifdb.NotificationType, bool) {
+	if name == "" {
+		return notifdb.NormalNotification, true
+	}
+
+	notiTypes := []notifdb.NotificationType{
+		notifdb.NormalNotification,
+		notifdb.StrictNotification,
+		notifdb.LenientNotification,
+	}
+	for _, notiType := range notiTypes {
+		if strings.EqualFold(notiType.String(), name) {
+			return notiType, true
+		}
+	}
+
+	return notifdb.NormalNotification, false
+}
+
+func parseDeliveryMethod(name string) (notifdb.DeliveryMethod, bool) {
+	if name == "" {
+		return notifdb.DMDelivery, true
+	}
+
+	for _, method := range notifdb.DeliveryMethods {
+		if strings.EqualFold(method.String(), name) {
+			return method, true
+		}
+	}
+
+	return notifdb.DMDelivery, false
+}
+
+func encodeNotificationsFile(
+	file notificationsFile, format int64) ([]byte, error) {
+
+	if format == csvTransferFormat {
+		return encodeNotificationsCSV(file)
+	}
+
+	return json.MarshalIndent(file, "", "  ")
+}
+
+func encodeNotificationsCSV(file notificationsFile) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	records := make([][]string, 0, len(file.Notifications)+1)
+	records = append(records, csvHeader)
+
+	for _, noti := range file.Notifications {
+		records = append(records, []string{
+			csvNotificationKind,
+			noti.Keyword,
+			noti.Type,
+			formatCSVSnowflake(discord.Snowflake(noti.GuildID)),
+			noti.Delivery,
+			strconv.FormatInt(int64(noti.CooldownSeconds), 10),
+			strconv.FormatBool(noti.IncludeBots),
+			formatCSVSnowflake(discord.Snowflake(noti.RequiredRoleID)),
+			formatCSVUserIDs(noti.OnlyUserIDs),
+			formatCSVUserIDs(noti.IgnoredUserIDs),
+			"",
+		})
+	}
+	for _, channelID := range file.ChannelMutes {
+		record := make([]string, len(csvHeader))
+		record[0] = csvChannelMuteKind
+		record[10] = channelID.String()
+		records = append(records, record)
+	}
+	for _, guildID := range file.GuildMutes {
+		record := make([]string, len(csvHeader))
+		record[0] = csvGuildMuteKind
+		record[3] = guildID.String()
+		records = append(records, record)
+	}
+	if file.DoNotDisturb {
+		record := make([]string, len(csvHeader))
+		record[0] = csvDnDKind
+		records = append(records, record)
+	}
+
+	err := writer.WriteAll(records)
+	return buf.Bytes(), err
+}
+
+func formatCSVSnowflake(snowflake discord.Snowflake) string {
+	if !snowflake.IsValid() {
+		return ""
+	}
+
+	return snowflake.String()
+}
+
+func formatCSVUserIDs(userIDs []discord.UserID) string {
+	ids := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = userID.String()
+	}
+
+	return strings.Join(ids, " ")
+}
+
+// decodeNotificationsFile decodes an exported notifications file, returning
+// descriptions of any CSV rows that could not be read.
+func decodeNotificationsFile(
+	fileName string, data []byte) (*notificationsFile, []string, error) {
+
+	if strings.HasSuffix(strings.ToLower(fileName), ".csv") {
+		return decodeNotificationsCSV(bytes.NewReader(data))
+	}
+
+	return decodeNotificationsJSON(data)
+}
+
+// rawNotificationsFile is a notifications file whose entries are decoded one
+// at a time, so that invalid entries can be reported without failing the
+// whole import.
+type rawNotificationsFile struct {
+	Notifications []json.RawMessage `json:"notifications"`
+	ChannelMutes  []json.RawMessage `json:"channelMutes"`
+	GuildMutes    []json.RawMessage `json:"guildMutes"`
+	DoNotDisturb  bool              `json:"doNotDisturb"`
+}
+
+func decodeNotificationsJSON(
+	data []byte) (*notificationsFile, []string, error) {
+
+	var raw rawNotificationsFile
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		file    = notificationsFile{DoNotDisturb: raw.DoNotDisturb}
+		invalid []string
+	)
+
+	for i, entry := range raw.Notifications {
+		var noti exportedNotification
+		err := json.Unmarshal(entry, &noti)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf(
+				"notification %d: invalid entry", i+1,
+			))
+			continue
+		}
+		file.Notifications = append(file.Notifications, noti)
+	}
+
+	for i, entry := range raw.ChannelMutes {
+		var channelID discord.ChannelID
+		err := json.Unmarshal(entry, &channelID)
+		if err != nil || !channelID.IsValid() {
+			invalid = append(invalid, fmt.Sprintf(
+				"channel mute %d: invalid channel ID", i+1,
+			))
+			continue
+		}
+		file.ChannelMutes = append(file.ChannelMutes, channelID)
+	}
+
+	for i, entry := range raw.GuildMutes {
+		var guildID discord.GuildID
+		err := json.Unmarshal(entry, &guildID)
+		if err != nil || !guildID.IsValid() {
+			invalid = append(invalid, fmt.Sprintf(
+				"server mute %d: invalid server ID", i+1,
+			))
+			continue
+		}
+		file.GuildMutes = append(file.GuildMutes, guildID)
+	}
+
+	return &file, invalid, nil
+}
+
+func decodeNotificationsCSV(
+	reader io.Reader) (*notificationsFile, []string, error) {
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		file    notificationsFile
+		invalid []string
+	)
+
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && record[0] == csvHeader[0] {
+			continue
+		}
+		if len(record) < len(csvHeader) {
+			padding := make([]string, len(csvHeader)-len(record))
+			record = append(record, padding...)
+		}
+
+		err := decodeCSVRecord(&file, record)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("row %d: %s", i+1, err))
+		}
+	}
+
+	return &file, invalid, nil
+}
+
+func decodeCSVRecord(file *notificationsFile, record []string) error {
+	switch record[0] {
+	case csvNotificationKind:
+		noti, err := decodeCSVNotification(record)
+		if err != nil {
+			return err
+		}
+		file.Notifications = append(file.Notifications, *noti)
+	case csvChannelMuteKind:
+		channelID, err := parseCSVSnowflake(record[10])
+		if err != nil || !channelID.IsValid() {
+			return fmt.Errorf("invalid channel ID")
+		}
+		file.ChannelMutes = append(
+			file.ChannelMutes, discord.ChannelID(channelID),
+		)
+	case csvGuildMuteKind:
+		guildID, err := parseCSVSnowflake(record[3])
+		if err != nil || !guildID.IsValid() {
+			return fmt.Errorf("invalid server ID")
+		}
+		file.GuildMutes = append(file.GuildMutes, discord.GuildID(guildID))
+	case csvDnDKind:
+		file.DoNotDisturb = true
+	default:
+		return fmt.Errorf("unknown entry kind %q", record[0])
+	}
+
+	return nil
+}
+
+func decodeCSVNotification(record []string) (*exportedNotification, error) {
+	guildID, err := parseCSVSnowflake(record[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid server ID")
+	}
+
+	var cooldown int64
+	if record[5] != "" {
+		cooldown, err = strconv.ParseInt(record[5], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cooldown")
+		}
+	}
+
+	var includeBots bool
+	if record[6] != "" {
+		includeBots, err = strconv.ParseBool(record[6])
+		if err != nil {
+			return nil, fmt.Errorf("invalid include bots value")
+		}
+	}
+
+	roleID, err := parseCSVSnowflake(record[7])
+	if err != nil {
+		return nil, fmt.Errorf("invalid role ID")
+	}
+
+	onlyUserIDs, err := parseCSVUserIDs(record[8])
+	if err != nil {
+		return nil, fmt.Errorf("invalid only user IDs")
+	}
+
+	ignoredUserIDs, err := parseCSVUserIDs(record[9])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignored user IDs")
+	}
+
+	return &exportedNotification{
+		Keyword:         record[1],
+		Type:            record[2],
+		GuildID:         discord.GuildID(guildID),
+		Delivery:        record[4],
+		CooldownSeconds: int32(cooldown),
+		IncludeBots:     includeBots,
+		RequiredRoleID:  discord.RoleID(roleID),
+		OnlyUserIDs:     onlyUserIDs,
+		IgnoredUserIDs:  ignoredUserIDs,
+	}, nil
+}
+
+func parseCSVSnowflake(field string) (discord.Snowflake, error) {
+	if field == "" {
+		return 0, nil
+	}
+
+	return discord.ParseSnowflake(field)
+}
+
+func parseCSVUserIDs(field string) ([]discord.UserID, error) {
+	fields := strings.Fields(field)
+	userIDs := make([]discord.UserID, len(fields))
+
+	for i, f := range fields {
+		snowflake, err := discord.ParseSnowflake(f)
+		if err != nil {
+			return nil, err
+		}
+		userIDs[i] = discord.UserID(snowflake)
+	}
+
+	return userIDs, nil
+}
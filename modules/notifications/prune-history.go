@@ -0,0 +1,22 @@
+package notifications
+
+import (
+	"log"
+	"time"
+)
+
+// pruneHistoryJob starts a job that removes expired notification history at
+// the provided interval.
+func pruneHistoryJob(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		deleted, err := db.Notifications.PruneHistory()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		log.Printf("Deleted %d expired notification history entries\n", deleted)
+	}
+}
@@ -1,37 +1,34 @@
 package reminders
 
 import (
+	"fmt"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/twoscott/haseul-bot-2/database/reminderdb"
 	"github.com/twoscott/haseul-bot-2/utils/dctools"
 )
 
-const interval = time.Second * 30
-
-func startCheckingReminders(st *state.State) {
-	for {
-		start := time.Now()
-		log.Println("Started checking reminders")
-
-		checkReminders(st)
-
-		elapsed := time.Since(start)
-		log.Printf(
-			"Finished checking reminders, took: %1.2fs\n", elapsed.Seconds(),
-		)
-
-		wait := interval - elapsed
-		<-time.After(wait)
-	}
-}
+const (
+	// lateThreshold is how long after a reminder is due that it is considered
+	// late when sent.
+	lateThreshold = time.Minute
+	// retryDelay is how long to wait before first retrying a reminder that
+	// failed to send. The delay doubles with each failed attempt.
+	retryDelay = time.Second * 30
+	// maxDeliveryAttempts is the number of times sending a reminder is
+	// attempted before it is marked as failed.
+	maxDeliveryAttempts = 6
+)
 
-func checkReminders(st *state.State) {
-	reminders, err := db.Reminders.GetOverdueReminders()
+// sendDueReminders sends all reminders that are due and waits for them to be
+// sent.
+func sendDueReminders(st *state.State) {
+	reminders, err := db.Reminders.GetDueBefore(time.Now())
 	if err != nil {
 		log.Println(err)
 		return
@@ -45,17 +42,241 @@ func checkReminders(st *state.State) {
 			sendReminder(st, r)
 		}(reminder)
 	}
+
+	wg.Wait()
 }
 
 func sendReminder(st *state.State, reminder reminderdb.Reminder) {
+	next, repeats := nextReminderTime(reminder)
+
+	var err error
+	if reminder.IsChannel() {
+		err = sendChannelReminder(st, reminder, next, repeats)
+	} else {
+		err = sendDMReminder(st, reminder, next, repeats)
+		if err != nil && shouldFallBack(reminder, err) {
+			log.Println(err)
+			err = sendFallbackReminder(st, reminder, next, repeats)
+		}
+	}
+	if err != nil {
+		log.Println(err)
+		if reminder.IsChannel() && channelInaccessible(err) {
+			log.Printf(
+				"Deleting reminder %d as channel %d is inaccessible\n",
+				reminder.ID, reminder.ChannelID,
+			)
+			db.Reminders.DeleteForUser(reminder.UserID, reminder.ID)
+			return
+		}
+
+		retryReminder(reminder, next, repeats)
+		return
+	}
+
+	if repeats {
+		err = db.Reminders.Reschedule(reminder.ID, next, nextRemaining(reminder))
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		scheduler.schedule(reminder.ID, next)
+		return
+	}
+
+	db.Reminders.DeleteForUser(reminder.UserID, reminder.ID)
+}
+
+func sendDMReminder(
+	st *state.State,
+	reminder reminderdb.Reminder,
+	next time.Time,
+	repeats bool) error {
+
 	dmChannel, err := st.CreatePrivateChannel(reminder.UserID)
+	if err != nil {
+		return err
+	}
+
+	msg := "⏰ Reminder has been triggered." +
+		lateReminderString(reminder) +
+		nextReminderString(next, repeats)
+
+	_, err = st.SendMessageComplex(dmChannel.ID, api.SendMessageData{
+		Content:    msg,
+		Embeds:     []discord.Embed{reminderEmbed(reminder)},
+		Components: reminderComponents(reminder.MessageLink),
+	})
+
+	return err
+}
+
+func sendChannelReminder(
+	st *state.State,
+	reminder reminderdb.Reminder,
+	next time.Time,
+	repeats bool) error {
+
+	msg := fmt.Sprintf(
+		"⏰ Reminder from %s.%s%s",
+		reminder.UserID.Mention(),
+		lateReminderString(reminder),
+		nextReminderString(next, repeats),
+	)
+
+	allowedMentions := &api.AllowedMentions{}
+	if reminder.RoleID.IsValid() {
+		msg = reminder.RoleID.Mention() + " " + msg
+		allowedMentions.Roles = []discord.RoleID{reminder.RoleID}
+	}
+
+	// one-off reminders are deleted once sent, so other users are offered a
+	// copy of the reminder rather than a subscription to it.
+	components := remindMeLaterComponents(reminder.MessageLink)
+	if repeats {
+		components = subscribeComponents(reminder.ID)
+	}
+
+	posted, err := st.SendMessageComplex(reminder.ChannelID, api.SendMessageData{
+		Content:         msg,
+		Embeds:          []discord.Embed{reminderEmbed(reminder)},
+		Components:      components,
+		AllowedMentions: allowedMentions,
+	})
+	if err != nil {
+		return err
+	}
+
+	posted.GuildID = reminder.GuildID
+	sendSubscriberReminders(st, reminder, posted.URL(), next, repeats)
+
+	return nil
+}
+
+// sendSubscriberReminders sends a reminder posted to a guild channel to the
+// DMs of all users subscribed to it.
+func sendSubscriberReminders(
+	st *state.State,
+	reminder reminderdb.Reminder,
+	messageLink string,
+	next time.Time,
+	repeats bool) {
+
+	userIDs, err := db.Reminders.GetSubscribers(reminder.ID)
 	if err != nil {
 		log.Println(err)
 		return
 	}
 
-	msg := "⏰ Reminder has been triggered."
-	_, err = st.SendMessage(dmChannel.ID, msg, discord.Embed{
+	msg := fmt.Sprintf(
+		"⏰ Reminder posted in %s has been triggered.%s",
+		reminder.ChannelID.Mention(), nextReminderString(next, repeats),
+	)
+
+	for _, userID := range userIDs {
+		dmChannel, err := st.CreatePrivateChannel(userID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		_, err = st.SendMessageComplex(dmChannel.ID, api.SendMessageData{
+			Content:    msg,
+			Embeds:     []discord.Embed{reminderEmbed(reminder)},
+			Components: reminderComponents(messageLink),
+		})
+		if err != nil && !dctools.ErrCannotDM(err) {
+			log.Println(err)
+		}
+	}
+}
+
+// sendFallbackReminder posts a reminder that could not be sent to the user's
+// DMs in the channel it was created in, mentioning the user.
+func sendFallbackReminder(
+	st *state.State,
+	reminder reminderdb.Reminder,
+	next time.Time,
+	repeats bool) error {
+
+	msg := fmt.Sprintf(
+		"%s ⏰ Reminder has been triggered, but I was unable to DM you.%s%s",
+		reminder.UserID.Mention(),
+		lateReminderString(reminder),
+		nextReminderString(next, repeats),
+	)
+
+	_, err := st.SendMessageComplex(
+		reminder.OriginChannelID,
+		api.SendMessageData{
+			Content: msg,
+			Embeds:  []discord.Embed{reminderEmbed(reminder)},
+			AllowedMentions: &api.AllowedMentions{
+				Users: []discord.UserID{reminder.UserID},
+			},
+		},
+	)
+
+	return err
+}
+
+// shouldFallBack returns whether a reminder that failed to send to the user's
+// DMs should be posted in the channel it was created in instead.
+func shouldFallBack(reminder reminderdb.Reminder, err error) bool {
+	if !reminder.OriginChannelID.IsValid() {
+		return false
+	}
+
+	lastAttempt := int(reminder.Attempts)+1 >= maxDeliveryAttempts
+	return dctools.ErrCannotDM(err) || lastAttempt
+}
+
+// retryReminder records a failed attempt to send a reminder and schedules it
+// to be retried with exponential backoff. Once the reminder has failed too
+// many times, recurring reminders skip to their next occurrence and other
+// reminders are marked as failed.
+func retryReminder(reminder reminderdb.Reminder, next time.Time, repeats bool) {
+	attempts := int(reminder.Attempts) + 1
+	if attempts < maxDeliveryAttempts {
+		retry := time.Now().Add(retryDelay << (attempts - 1))
+		err := db.Reminders.RecordFailedAttempt(reminder.ID, retry)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		scheduler.schedule(reminder.ID, retry)
+		return
+	}
+
+	if repeats {
+		err := db.Reminders.Reschedule(reminder.ID, next, nextRemaining(reminder))
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		scheduler.schedule(reminder.ID, next)
+		return
+	}
+
+	log.Printf(
+		"Reminder %d failed to send after %d attempts\n", reminder.ID, attempts,
+	)
+
+	err := db.Reminders.MarkFailed(reminder.ID)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func channelInaccessible(err error) bool {
+	return dctools.ErrUnknownChannel(err) || dctools.ErrMissingAccess(err)
+}
+
+func reminderEmbed(reminder reminderdb.Reminder) discord.Embed {
+	return discord.Embed{
 		Author: &discord.EmbedAuthor{
 			Name: "Reminder",
 		},
@@ -65,11 +286,58 @@ func sendReminder(st *state.State, reminder reminderdb.Reminder) {
 			Text: "Reminder set on",
 		},
 		Timestamp: discord.Timestamp(reminder.Created),
-	})
+	}
+}
+
+func lateReminderString(reminder reminderdb.Reminder) string {
+	if time.Since(reminder.Time) < lateThreshold {
+		return ""
+	}
+
+	return " This reminder is late, it was due " +
+		dctools.TimestampStyled(reminder.Time, dctools.RelativeTime) + "."
+}
+
+func nextReminderString(next time.Time, repeats bool) string {
+	if !repeats {
+		return ""
+	}
+
+	return " Next reminder " +
+		dctools.TimestampStyled(next, dctools.RelativeTime) + "."
+}
+
+// nextReminderTime returns the next time a recurring reminder should be sent
+// after it is sent now, and whether the reminder repeats again at all.
+func nextReminderTime(reminder reminderdb.Reminder) (time.Time, bool) {
+	if !reminder.IsRecurring() || reminder.Remaining == 1 {
+		return time.Time{}, false
+	}
+
+	sched, err := parseSchedule(reminder.Expression)
 	if err != nil {
 		log.Println(err)
-		return
+		return time.Time{}, false
 	}
 
-	db.Reminders.DeleteForUser(reminder.UserID, reminder.ID)
+	loc := userLocation(reminder.UserID)
+	next := nextOccurrence(sched, reminder.Time.In(loc), time.Now())
+	if next.IsZero() {
+		return next, false
+	}
+	if reminder.EndTime != nil && next.After(*reminder.EndTime) {
+		return next, false
+	}
+
+	return next, true
+}
+
+// nextRemaining returns the number of times a recurring reminder is still to
+// be sent after it is sent now.
+func nextRemaining(reminder reminderdb.Reminder) int32 {
+	if reminder.Remaining > 0 {
+		return reminder.Remaining - 1
+	}
+
+	return 0
 }
@@ -12,14 +12,27 @@ func Init(rt *router.Router) {
 	db = database.GetInstance()
 
 	rt.AddStartupListener(onStartup)
+	rt.AddSelectListener(handleReminderSnooze)
+	rt.AddSelectListener(handleRemindMeLater)
+	rt.AddButtonListener(handleReminderDone)
+	rt.AddButtonListener(handleRemindMeToo)
 
 	rt.AddCommand(remindersCommand)
 	remindersCommand.AddSubCommand(remindersAddCommand)
 	remindersCommand.AddSubCommand(remindersClearCommand)
 	remindersCommand.AddSubCommand(remindersDeleteCommand)
+	remindersCommand.AddSubCommand(remindersEditCommand)
 	remindersCommand.AddSubCommand(remindersListCommand)
+	remindersCommand.AddSubCommand(remindersRepeatCommand)
+
+	rt.AddCommand(remindMeCommand)
+
+	remindersCommand.AddSubCommandGroup(remindersChannelCommand)
+	remindersChannelCommand.AddSubCommand(remindersChannelAddCommand)
+	remindersChannelCommand.AddSubCommand(remindersChannelDeleteCommand)
+	remindersChannelCommand.AddSubCommand(remindersChannelListCommand)
 }
 
 func onStartup(rt *router.Router, _ *gateway.ReadyEvent) {
-	startCheckingReminders(rt.State)
+	scheduler.start(rt.State)
 }
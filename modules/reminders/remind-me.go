@@ -0,0 +1,150 @@
+package reminders
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/reminderdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var remindMeCommand = &router.Command{
+	Name: "Remind Me",
+	Type: discord.MessageCommand,
+	Handler: &router.CommandHandler{
+		Executor:  remindMeExec,
+		Ephemeral: true,
+	},
+}
+
+func remindMeExec(ctx router.CommandCtx) {
+	msg, ok := ctx.Command.Resolved.Messages[ctx.Command.TargetMessageID()]
+	if !ok {
+		ctx.RespondError("Error occurred while fetching message data.")
+		return
+	}
+	msg.GuildID = ctx.Interaction.GuildID
+
+	if !checkReminderLimit(ctx.InteractionCtx) {
+		return
+	}
+
+	timeBox := &discord.TextInputComponent{
+		CustomID:     "TIME",
+		Label:        "When",
+		Style:        discord.TextInputShortStyle,
+		Placeholder:  "e.g. 3 hours, tomorrow 9am, next friday",
+		Required:     true,
+		LengthLimits: [2]int{1, 64},
+	}
+
+	noteBox := &discord.TextInputComponent{
+		CustomID:     "NOTE",
+		Label:        "Note",
+		Style:        discord.TextInputParagraphStyle,
+		Placeholder:  "What to be reminded of about this message.",
+		Value:        defaultNote(msg),
+		LengthLimits: [2]int{0, 2048},
+	}
+
+	customID := ctx.Interaction.ID.String()
+
+	evCh, cancel := ctx.State.ChanFor(func(ev interface{}) bool {
+		i, ok := ev.(*gateway.InteractionCreateEvent)
+		if !ok {
+			return ok
+		}
+
+		m, ok := i.Data.(*discord.ModalInteraction)
+		return ok && m.CustomID == discord.ComponentID(customID)
+	})
+	defer cancel()
+
+	err := ctx.RespondModal(
+		api.InteractionResponseData{
+			CustomID:   option.NewNullableString(customID),
+			Title:      option.NewNullableString("Remind Me"),
+			Components: discord.ComponentsPtr(timeBox, noteBox),
+		},
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondGenericError()
+		return
+	}
+
+	var (
+		submit *discord.InteractionEvent
+		modal  *discord.ModalInteraction
+	)
+	select {
+	case ev := <-evCh:
+		icv := ev.(*gateway.InteractionCreateEvent)
+		submit = &icv.InteractionEvent
+		modal = submit.Data.(*discord.ModalInteraction)
+	case <-time.After(30 * time.Minute):
+		ctx.RespondWarning("Modal timed out.")
+		return
+	}
+	if submit == nil || modal == nil {
+		ctx.RespondGenericError()
+		return
+	}
+
+	modalCtx := router.ModalCtx{
+		InteractionCtx: &router.InteractionCtx{
+			Router:      ctx.Router,
+			Interaction: submit,
+			Ephemeral:   true,
+		},
+		Modal: modal,
+	}
+
+	processRemindMeSubmit(modalCtx, msg)
+}
+
+func processRemindMeSubmit(ctx router.ModalCtx, msg discord.Message) {
+	timeComponent, ok := ctx.Modal.Components.
+		Find("TIME").(*discord.TextInputComponent)
+	if !ok {
+		ctx.RespondError("Error occurred processing submitted modal data.")
+		return
+	}
+	noteComponent, ok := ctx.Modal.Components.
+		Find("NOTE").(*discord.TextInputComponent)
+	if !ok {
+		ctx.RespondError("Error occurred processing submitted modal data.")
+		return
+	}
+
+	startTime := time.Now().In(userLocation(ctx.Interaction.SenderID()))
+	newTime, cerr := parseReminderTime(timeComponent.Value, startTime)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	note := noteComponent.Value
+	if note == "" {
+		note = fmt.Sprintf("Message from %s", msg.Author.Mention())
+	}
+
+	addUserReminder(
+		ctx.InteractionCtx, newTime, note, msg.URL(), reminderdb.Schedule{},
+	)
+}
+
+// defaultNote returns the note a reminder about a message is prefilled with.
+func defaultNote(msg discord.Message) string {
+	content := []rune(msg.Content)
+	if len(content) > 2048 {
+		content = content[:2048]
+	}
+
+	return string(content)
+}
@@ -0,0 +1,415 @@
+package reminders
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const (
+	selectIDSnoozeReminder = "SNOOZE_REMINDER"
+	buttonIDReminderDone   = "REMINDER_DONE"
+	buttonIDRemindMeToo    = "REMIND_ME_TOO"
+	selectIDRemindMeLater  = "REMIND_ME_LATER"
+
+	messageLinkPrefix = "https://discord.com/channels/"
+)
+
+// snoozeOptions maps snooze select values to the time a reminder is snoozed
+// until, given the current time in the user's timezone.
+var snoozeOptions = []struct {
+	label string
+	value string
+	until func(now time.Time) time.Time
+}{
+	{
+		label: "10 minutes",
+		value: "10m",
+		until: func(now time.Time) time.Time {
+			return now.Add(10 * time.Minute)
+		},
+	},
+	{
+		label: "1 hour",
+		value: "1h",
+		until: func(now time.Time) time.Time {
+			return now.Add(time.Hour)
+		},
+	},
+	{
+		label: "Tomorrow",
+		value: "tomorrow",
+		until: func(now time.Time) time.Time {
+			t, _ := util.ParseDateTime("tomorrow", now)
+			return t
+		},
+	},
+}
+
+// reminderComponents returns the snooze select and done button attached to
+// reminders sent to users' DMs, along with a jump button if the reminder is
+// about a message. The message link is stored in the snooze select's custom
+// ID so that it is kept when the reminder is snoozed.
+func reminderComponents(messageLink string) discord.ContainerComponents {
+	options := make([]discord.SelectOption, len(snoozeOptions))
+	for i, o := range snoozeOptions {
+		options[i] = discord.SelectOption{
+			Label: o.label,
+			Value: o.value,
+			Emoji: &discord.ComponentEmoji{Name: "💤"},
+		}
+	}
+
+	selectID := selectIDSnoozeReminder
+	doneID := buttonIDReminderDone
+	if strings.HasPrefix(messageLink, messageLinkPrefix) {
+		path := strings.TrimPrefix(messageLink, messageLinkPrefix)
+		selectID += ":" + path
+		doneID += ":" + path
+	}
+
+	buttons := discord.ActionRowComponent{
+		&discord.ButtonComponent{
+			Label:    "Done",
+			CustomID: discord.ComponentID(doneID),
+			Style:    discord.SuccessButtonStyle(),
+			Emoji:    &discord.ComponentEmoji{Name: "✅"},
+		},
+	}
+	buttons = append(buttons, jumpButtons(messageLink)...)
+
+	return discord.Components(
+		&discord.ActionRowComponent{
+			&discord.StringSelectComponent{
+				Options:     options,
+				CustomID:    discord.ComponentID(selectID),
+				Placeholder: "Snooze",
+			},
+		},
+		&buttons,
+	)
+}
+
+// jumpButtons returns a button linking to the message a reminder is about, or
+// no buttons if the reminder is not about a message.
+func jumpButtons(messageLink string) discord.ActionRowComponent {
+	if messageLink == "" {
+		return nil
+	}
+
+	return discord.ActionRowComponent{
+		&discord.ButtonComponent{
+			Label: "Jump to Message",
+			Style: discord.LinkButtonStyle(messageLink),
+		},
+	}
+}
+
+// parseComponentLink parses the message link stored in a reminder
+// component's custom ID, returning false if the custom ID does not belong to
+// the component.
+func parseComponentLink(
+	customID discord.ComponentID, componentID string) (string, bool) {
+
+	args := strings.SplitN(string(customID), ":", 2)
+	if args[0] != componentID {
+		return "", false
+	}
+	if len(args) < 2 {
+		return "", true
+	}
+
+	return messageLinkPrefix + args[1], true
+}
+
+func handleReminderSnooze(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	data *discord.StringSelectInteraction) {
+
+	messageLink, ok := parseComponentLink(
+		data.CustomID, selectIDSnoozeReminder,
+	)
+	if !ok || len(data.Values) < 1 {
+		return
+	}
+
+	snoozeTime, cerr := addReminderCopy(
+		interaction, data.Values[0], messageLink,
+	)
+	if cerr != nil {
+		respondReminderComponent(rt, interaction, cerr)
+		return
+	}
+	if snoozeTime.IsZero() {
+		return
+	}
+
+	content := "💤 Reminder snoozed until " +
+		dctools.TimestampStyled(snoozeTime, dctools.LongDateTime) + "."
+	updateReminderMessage(rt, interaction, content, messageLink)
+}
+
+// addReminderCopy adds a reminder for the user who used a component on a sent
+// reminder, with the same content as the sent reminder, for the time chosen
+// from the snooze options. It returns the zero time if the sent reminder or
+// the chosen option is invalid.
+func addReminderCopy(
+	interaction *discord.InteractionEvent,
+	value string,
+	messageLink string) (time.Time, router.CmdResponse) {
+
+	msg := interaction.Message
+	if msg == nil || len(msg.Embeds) < 1 {
+		return time.Time{}, nil
+	}
+
+	userID := interaction.SenderID()
+	now := time.Now().In(userLocation(userID))
+
+	var remindTime time.Time
+	for _, o := range snoozeOptions {
+		if o.value == value {
+			remindTime = o.until(now)
+		}
+	}
+	if remindTime.IsZero() {
+		return time.Time{}, nil
+	}
+
+	pending, err := db.Reminders.GetAllByUser(userID)
+	if err != nil {
+		log.Println(err)
+		return time.Time{}, router.Error(
+			"Error occurred while checking pending reminders.",
+		)
+	}
+	if len(pending) >= reminderLimit {
+		return time.Time{}, router.Warningf(
+			"You cannot have more than %d pending reminders at once.",
+			reminderLimit,
+		)
+	}
+
+	embed := msg.Embeds[0]
+	created := embed.Timestamp.Time()
+	if !embed.Timestamp.IsValid() {
+		created = time.Now()
+	}
+
+	reminderID, err := db.Reminders.AddSnoozed(
+		userID, remindTime, embed.Description, messageLink, created,
+	)
+	if err != nil {
+		log.Println(err)
+		return time.Time{}, router.Error(
+			"Error occurred while adding the reminder.",
+		)
+	}
+
+	scheduler.schedule(reminderID, remindTime)
+
+	return remindTime, nil
+}
+
+func handleReminderDone(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	data *discord.ButtonInteraction) {
+
+	messageLink, ok := parseComponentLink(data.CustomID, buttonIDReminderDone)
+	if !ok {
+		return
+	}
+
+	updateReminderMessage(
+		rt, interaction, "✅ Reminder marked as done.", messageLink,
+	)
+}
+
+// subscribeComponents returns the button attached to reminders posted to
+// guild channels that lets other users subscribe to the reminder.
+func subscribeComponents(reminderID int32) discord.ContainerComponents {
+	return discord.Components(
+		&discord.ActionRowComponent{
+			&discord.ButtonComponent{
+				Label: "Remind Me Too",
+				CustomID: discord.ComponentID(
+					fmt.Sprintf("%s:%d", buttonIDRemindMeToo, reminderID),
+				),
+				Style: discord.SecondaryButtonStyle(),
+				Emoji: &discord.ComponentEmoji{Name: "🔔"},
+			},
+		},
+	)
+}
+
+// remindMeLaterComponents returns the select attached to one-off reminders
+// posted to guild channels that lets other users be sent a copy of the
+// reminder in their DMs at a later time. The message link of the reminder is
+// stored in the select's custom ID so that it is kept in the copy.
+func remindMeLaterComponents(messageLink string) discord.ContainerComponents {
+	options := make([]discord.SelectOption, len(snoozeOptions))
+	for i, o := range snoozeOptions {
+		options[i] = discord.SelectOption{
+			Label: o.label,
+			Value: o.value,
+			Emoji: &discord.ComponentEmoji{Name: "🔔"},
+		}
+	}
+
+	selectID := selectIDRemindMeLater
+	if strings.HasPrefix(messageLink, messageLinkPrefix) {
+		selectID += ":" + strings.TrimPrefix(messageLink, messageLinkPrefix)
+	}
+
+	return discord.Components(
+		&discord.ActionRowComponent{
+			&discord.StringSelectComponent{
+				Options:     options,
+				CustomID:    discord.ComponentID(selectID),
+				Placeholder: "Remind Me Later",
+			},
+		},
+	)
+}
+
+func handleRemindMeLater(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	data *discord.StringSelectInteraction) {
+
+	messageLink, ok := parseComponentLink(data.CustomID, selectIDRemindMeLater)
+	if !ok || len(data.Values) < 1 {
+		return
+	}
+
+	remindTime, cerr := addReminderCopy(
+		interaction, data.Values[0], messageLink,
+	)
+	if cerr != nil {
+		respondReminderComponent(rt, interaction, cerr)
+		return
+	}
+	if remindTime.IsZero() {
+		return
+	}
+
+	respondReminderComponent(rt, interaction, router.Successf(
+		"You will be sent this reminder in your DMs %s.",
+		dctools.TimestampStyled(remindTime, dctools.RelativeTime),
+	))
+}
+
+func handleRemindMeToo(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	data *discord.ButtonInteraction) {
+
+	args := strings.Split(string(data.CustomID), ":")
+	if len(args) != 2 || args[0] != buttonIDRemindMeToo {
+		return
+	}
+
+	id, err := strconv.ParseInt(args[1], 10, 32)
+	if err != nil {
+		return
+	}
+	reminderID := int32(id)
+
+	_, err = db.Reminders.GetReminder(reminderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondReminderComponent(rt, interaction, router.Warning(
+			"This reminder will no longer be sent.",
+		))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		respondReminderComponent(rt, interaction, router.Error(
+			"Error occurred while fetching the reminder.",
+		))
+		return
+	}
+
+	userID := interaction.SenderID()
+	added, err := db.Reminders.AddSubscriber(reminderID, userID)
+	if err != nil {
+		log.Println(err)
+		respondReminderComponent(rt, interaction, router.Error(
+			"Error occurred while subscribing you to the reminder.",
+		))
+		return
+	}
+	if added {
+		respondReminderComponent(rt, interaction, router.Success(
+			"You will now be sent this reminder in your DMs. "+
+				"Press the button again to unsubscribe.",
+		))
+		return
+	}
+
+	_, err = db.Reminders.RemoveSubscriber(reminderID, userID)
+	if err != nil {
+		log.Println(err)
+		respondReminderComponent(rt, interaction, router.Error(
+			"Error occurred while unsubscribing you from the reminder.",
+		))
+		return
+	}
+
+	respondReminderComponent(rt, interaction, router.Success(
+		"You will no longer be sent this reminder in your DMs.",
+	))
+}
+
+// updateReminderMessage replaces the content of a sent reminder and removes
+// its components, keeping only the jump button if it has one.
+func updateReminderMessage(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	content string,
+	messageLink string) {
+
+	components := discord.ContainerComponents{}
+	if messageLink != "" {
+		jump := jumpButtons(messageLink)
+		components = discord.Components(&jump)
+	}
+
+	err := rt.State.RespondInteraction(
+		interaction.ID,
+		interaction.Token,
+		*dctools.UpdateMessageResponse(api.InteractionResponseData{
+			Content:    option.NewNullableString(content),
+			Components: &components,
+		}),
+	)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func respondReminderComponent(
+	rt *router.Router,
+	interaction *discord.InteractionEvent,
+	response router.CmdResponse) {
+
+	dctools.MessageRespond(rt.State, interaction,
+		api.InteractionResponseData{
+			Content: option.NewNullableString(response.String()),
+			Flags:   discord.EphemeralMessage,
+		},
+	)
+}
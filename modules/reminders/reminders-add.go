@@ -7,6 +7,7 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/reminderdb"
 	"github.com/twoscott/haseul-bot-2/router"
 	"github.com/twoscott/haseul-bot-2/utils/dctools"
 	"github.com/twoscott/haseul-bot-2/utils/util"
@@ -21,28 +22,58 @@ var remindersAddCommand = &router.SubCommand{
 		Executor:  remindersAddExec,
 		Ephemeral: true,
 	},
-	Options: []discord.CommandOptionValue{
-		&discord.StringOption{
-			OptionName:  "duration",
-			Description: "How long from now to wait until reminding you",
-			MaxLength:   option.NewInt(64),
-			Required:    true,
+	Options: append(
+		[]discord.CommandOptionValue{
+			&discord.StringOption{
+				OptionName: "time",
+				Description: "When to remind you, e.g. 3 hours, tomorrow 9am, " +
+					"next friday",
+				MaxLength: option.NewInt(64),
+				Required:  true,
+			},
+			&discord.StringOption{
+				OptionName:  "reminder",
+				Description: "What to be reminded of",
+				MaxLength:   option.NewInt(2048),
+				Required:    true,
+			},
 		},
-		&discord.StringOption{
-			OptionName:  "reminder",
-			Description: "What to be reminded of",
-			MaxLength:   option.NewInt(2048),
-			Required:    true,
-		},
-	},
+		scheduleOptions...,
+	),
 }
 
 func remindersAddExec(ctx router.CommandCtx) {
+	if !checkReminderLimit(ctx.InteractionCtx) {
+		return
+	}
+
+	startTime := time.Now().In(userLocation(ctx.Interaction.SenderID()))
+	timeString := ctx.Options.Find("time").String()
+	reminder := ctx.Options.Find("reminder").String()
+
+	newTime, cerr := parseReminderTime(timeString, startTime)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	schedule, cerr := parseScheduleOptions(ctx, startTime)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	addUserReminder(ctx.InteractionCtx, newTime, reminder, "", schedule)
+}
+
+// checkReminderLimit responds with a warning and returns false if the user
+// has reached the limit of pending reminders.
+func checkReminderLimit(ctx *router.InteractionCtx) bool {
 	pending, err := db.Reminders.GetAllByUser(ctx.Interaction.SenderID())
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while checking pending reminders.")
-		return
+		return false
 	}
 
 	if len(pending) >= reminderLimit {
@@ -52,22 +83,20 @@ func remindersAddExec(ctx router.CommandCtx) {
 				reminderLimit,
 			),
 		)
-		return
+		return false
 	}
 
-	startTime := time.Now()
-	durationString := ctx.Options.Find("duration").String()
-	reminder := ctx.Options.Find("reminder").String()
-
-	timePeriod := util.ParseTimePeriod(durationString)
-	if timePeriod.IsNull() {
-		ctx.RespondWarning(
-			"Invalid time period given. Example format: `3 days 4hr 6 min 2s`",
-		)
-		return
-	}
+	return true
+}
 
-	newTime := timePeriod.AfterTime(startTime)
+// addUserReminder adds a reminder to be sent to the user's DMs, confirming
+// the reminder in their DMs.
+func addUserReminder(
+	ctx *router.InteractionCtx,
+	newTime time.Time,
+	reminder string,
+	messageLink string,
+	schedule reminderdb.Schedule) {
 
 	dmChannel, err := ctx.State.CreatePrivateChannel(ctx.Interaction.SenderID())
 	if err != nil {
@@ -78,7 +107,20 @@ func remindersAddExec(ctx router.CommandCtx) {
 		return
 	}
 
-	reminderId, err := db.Reminders.Add(ctx.Interaction.SenderID(), newTime, reminder)
+	// fall back to the channel the reminder was set in if DMs fail.
+	var originChannelID discord.ChannelID
+	if ctx.Interaction.GuildID.IsValid() {
+		originChannelID = ctx.Interaction.ChannelID
+	}
+
+	reminderId, err := db.Reminders.AddRecurring(
+		ctx.Interaction.SenderID(),
+		originChannelID,
+		newTime,
+		reminder,
+		messageLink,
+		schedule,
+	)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError(
@@ -93,6 +135,17 @@ func remindersAddExec(ctx router.CommandCtx) {
 	)
 
 	_, err = ctx.State.SendMessage(dmChannel.ID, dmMsg)
+	if dctools.ErrCannotDM(err) && originChannelID.IsValid() {
+		scheduler.schedule(reminderId, newTime)
+		ctx.RespondWarningf(
+			"I am unable to DM you, so your reminder will be posted in %s on "+
+				"%s instead. Please open your DMs to server members in your "+
+				"settings to receive it privately.",
+			originChannelID.Mention(),
+			dctools.TimestampStyled(newTime, dctools.LongDateTime),
+		)
+		return
+	}
 	if dctools.ErrCannotDM(err) {
 		ctx.RespondWarning(
 			"I am unable to DM you. " +
@@ -102,10 +155,39 @@ func remindersAddExec(ctx router.CommandCtx) {
 		return
 	}
 
-	ctx.RespondSuccess(
-		fmt.Sprintf(
-			"Reminder set for %s.",
-			dctools.TimestampStyled(newTime, dctools.LongDateTime),
-		),
+	scheduler.schedule(reminderId, newTime)
+
+	response := fmt.Sprintf(
+		"Reminder set for %s.",
+		dctools.TimestampStyled(newTime, dctools.LongDateTime),
 	)
+	if schedule.IsRecurring() {
+		response += " It will repeat " + describeSchedule(schedule) + "."
+	}
+
+	ctx.RespondSuccess(response)
+}
+
+// parseReminderTime parses the time a reminder is due from either an absolute
+// time in the location of now, or a time period after now.
+func parseReminderTime(
+	input string, now time.Time) (time.Time, router.CmdResponse) {
+
+	dateTime, ok := util.ParseDateTime(input, now)
+	if ok {
+		if !dateTime.After(now) {
+			return time.Time{}, router.Warning("Time must be in the future.")
+		}
+		return dateTime, nil
+	}
+
+	timePeriod := util.ParseTimePeriod(input)
+	if timePeriod.IsNull() {
+		return time.Time{}, router.Warning(
+			"Invalid time given. Example formats: `3 days 4hr 6 min 2s`, " +
+				"`tomorrow 9am`, `Oct 31 18:00`, `next friday`",
+		)
+	}
+
+	return timePeriod.AfterTime(now), nil
 }
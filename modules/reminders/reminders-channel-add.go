@@ -0,0 +1,191 @@
+package reminders
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/reminderdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var remindersChannelAddCommand = &router.SubCommand{
+	Name:        "add",
+	Description: "Post a reminder to a channel in the future",
+	Handler: &router.CommandHandler{
+		Executor:  remindersChannelAddExec,
+		Ephemeral: true,
+	},
+	Options: append(
+		[]discord.CommandOptionValue{
+			&discord.ChannelOption{
+				OptionName:   "channel",
+				Description:  "The channel to post the reminder in",
+				Required:     true,
+				ChannelTypes: dctools.TextChannelTypes(),
+			},
+			&discord.StringOption{
+				OptionName: "time",
+				Description: "When to post the reminder, e.g. 3 hours, tomorrow 9am, " +
+					"next friday",
+				MaxLength: option.NewInt(64),
+				Required:  true,
+			},
+			&discord.StringOption{
+				OptionName:  "reminder",
+				Description: "What to remind the channel of",
+				MaxLength:   option.NewInt(2048),
+				Required:    true,
+			},
+			&discord.RoleOption{
+				OptionName:  "role",
+				Description: "The role to mention when posting the reminder",
+			},
+		},
+		scheduleOptions...,
+	),
+}
+
+func remindersChannelAddExec(ctx router.CommandCtx) {
+	snowflake, _ := ctx.Options.Find("channel").SnowflakeValue()
+	channelID := discord.ChannelID(snowflake)
+	channel, cerr := ctx.ParseSendableChannel(channelID)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	if !checkManageMessages(ctx, channel.ID) {
+		return
+	}
+
+	snowflake, _ = ctx.Options.Find("role").SnowflakeValue()
+	roleID := discord.RoleID(snowflake)
+	if roleID.IsValid() {
+		cerr := checkRoleMentionable(ctx, channel.ID, roleID)
+		if cerr != nil {
+			ctx.RespondCmdMessage(cerr)
+			return
+		}
+	}
+
+	pending, err := db.Reminders.GetAllByGuild(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking pending reminders.")
+		return
+	}
+	if len(pending) >= guildReminderLimit {
+		ctx.RespondWarningf(
+			"A server cannot have more than %d pending channel reminders.",
+			guildReminderLimit,
+		)
+		return
+	}
+
+	startTime := time.Now().In(userLocation(ctx.Interaction.SenderID()))
+	timeString := ctx.Options.Find("time").String()
+	reminder := ctx.Options.Find("reminder").String()
+
+	newTime, cerr := parseReminderTime(timeString, startTime)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	schedule, cerr := parseScheduleOptions(ctx, startTime)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	target := reminderdb.Target{
+		GuildID:   ctx.Interaction.GuildID,
+		ChannelID: channel.ID,
+		RoleID:    roleID,
+	}
+
+	reminderID, err := db.Reminders.AddToChannel(
+		ctx.Interaction.SenderID(), target, newTime, reminder, schedule,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError(
+			"Error occurred while adding reminder to the database.",
+		)
+		return
+	}
+
+	scheduler.schedule(reminderID, newTime)
+
+	response := fmt.Sprintf(
+		"Reminder will be posted in %s on %s.",
+		channel.Mention(),
+		dctools.TimestampStyled(newTime, dctools.LongDateTime),
+	)
+	if schedule.IsRecurring() {
+		response += " It will repeat " + describeSchedule(schedule) + "."
+	}
+
+	ctx.RespondSuccess(response)
+}
+
+// checkRoleMentionable checks that both the user and the bot are able to
+// mention the role in the channel, so that users cannot use reminders to
+// mention roles they would not be able to mention themselves.
+func checkRoleMentionable(
+	ctx router.CommandCtx,
+	channelID discord.ChannelID,
+	roleID discord.RoleID) router.CmdResponse {
+
+	role, err := ctx.State.Role(ctx.Interaction.GuildID, roleID)
+	if err != nil {
+		log.Println(err)
+		return router.Error("Error occurred while fetching the role.")
+	}
+	if role.Mentionable {
+		return nil
+	}
+
+	if !canMentionEveryone(ctx, channelID, ctx.Interaction.SenderID()) {
+		return router.Warningf(
+			"You do not have permission to mention %s in %s.",
+			roleID.Mention(), channelID.Mention(),
+		)
+	}
+
+	bot, err := ctx.State.Me()
+	if err != nil {
+		log.Println(err)
+		return router.Error("Error occurred while checking my permissions.")
+	}
+
+	if !canMentionEveryone(ctx, channelID, bot.ID) {
+		return router.Warningf(
+			"I am unable to mention %s in %s. Please make the role "+
+				"mentionable or give me permission to mention all roles.",
+			roleID.Mention(), channelID.Mention(),
+		)
+	}
+
+	return nil
+}
+
+// canMentionEveryone returns whether the user is able to mention all roles in
+// the channel.
+func canMentionEveryone(
+	ctx router.CommandCtx,
+	channelID discord.ChannelID,
+	userID discord.UserID) bool {
+
+	permissions, err := ctx.State.Permissions(channelID, userID)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+
+	return permissions.Has(discord.PermissionMentionEveryone)
+}
@@ -0,0 +1,78 @@
+package reminders
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/reminderdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var remindersChannelDeleteCommand = &router.SubCommand{
+	Name:        "delete",
+	Description: "Delete a reminder posted to a channel in the server",
+	Handler: &router.CommandHandler{
+		Executor:      remindersChannelDeleteExec,
+		Autocompleter: completeGuildReminder,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:   "reminder",
+			Description:  "The reminder to delete",
+			Required:     true,
+			Autocomplete: true,
+		},
+	},
+}
+
+func remindersChannelDeleteExec(ctx router.CommandCtx) {
+	if !checkManageMessages(ctx, ctx.Interaction.ChannelID) {
+		return
+	}
+
+	reminderID, _ := ctx.Options.Find("reminder").IntValue()
+
+	ok, err := db.Reminders.DeleteForGuild(
+		ctx.Interaction.GuildID, int32(reminderID),
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while trying to delete reminder.")
+		return
+	}
+	if !ok {
+		ctx.RespondWarning("I could not find this reminder.")
+		return
+	}
+
+	scheduler.cancel(int32(reminderID))
+
+	ctx.RespondSuccess("Reminder deleted.")
+}
+
+func completeGuildReminder(ctx router.AutocompleteCtx) {
+	reminders, err := db.Reminders.GetAllByGuild(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+	}
+
+	slices.SortFunc(reminders, func(a, b reminderdb.Reminder) int {
+		return int(a.Time.Unix() - b.Time.Unix())
+	})
+
+	choices := make(api.AutocompleteIntegerChoices, 0, len(reminders))
+	for _, r := range reminders {
+		choice := discord.IntegerChoice{
+			Name:  fmt.Sprintln(dctools.EmbedTime(r.Time), "-", r.Content),
+			Value: int(r.ID),
+		}
+		choices = append(choices, choice)
+	}
+
+	ctx.RespondChoices(choices)
+}
@@ -0,0 +1,36 @@
+package reminders
+
+import (
+	"log"
+
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var remindersChannelListCommand = &router.SubCommand{
+	Name:        "list",
+	Description: "List all the pending reminders posted to channels in the server",
+	Handler: &router.CommandHandler{
+		Executor:  remindersChannelListExec,
+		Ephemeral: true,
+	},
+}
+
+func remindersChannelListExec(ctx router.CommandCtx) {
+	if !checkManageMessages(ctx, ctx.Interaction.ChannelID) {
+		return
+	}
+
+	reminders, err := db.Reminders.GetAllByGuild(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching reminders.")
+		return
+	}
+
+	if len(reminders) < 1 {
+		ctx.RespondWarning("This server doesn't have any pending reminders.")
+		return
+	}
+
+	respondReminderList(ctx, "Pending Channel Reminders", reminders)
+}
@@ -0,0 +1,49 @@
+package reminders
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+const guildReminderLimit = 50
+
+var remindersChannelCommand = &router.SubCommandGroup{
+	Name:        "channel",
+	Description: "Commands pertaining to reminders posted to server channels",
+}
+
+// checkManageMessages responds with a warning and returns false if the user
+// is not in a server or does not have the Manage Messages permission in the
+// channel.
+func checkManageMessages(
+	ctx router.CommandCtx, channelID discord.ChannelID) bool {
+
+	if !ctx.Interaction.GuildID.IsValid() {
+		ctx.RespondWarning("Channel reminders can only be managed in a server.")
+		return false
+	}
+
+	permissions, err := ctx.State.Permissions(
+		channelID, ctx.Interaction.SenderID(),
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking your permissions.")
+		return false
+	}
+	if !dctools.HasAnyPermOrAdmin(
+		permissions, discord.PermissionManageMessages) {
+
+		ctx.RespondWarningf(
+			"You need the Manage Messages permission in %s to manage "+
+				"channel reminders.",
+			channelID.Mention(),
+		)
+		return false
+	}
+
+	return true
+}
@@ -17,7 +17,7 @@ var remindersDeleteCommand = &router.SubCommand{
 	Description: "Delete a reminder you previously set",
 	Handler: &router.CommandHandler{
 		Executor:      remindersDeleteExec,
-		Autocompleter: completeReminderDelete,
+		Autocompleter: completeReminder,
 		Ephemeral:     true,
 	},
 	Options: []discord.CommandOptionValue{
@@ -44,10 +44,12 @@ func remindersDeleteExec(ctx router.CommandCtx) {
 		return
 	}
 
+	scheduler.cancel(int32(reminderID))
+
 	ctx.RespondSuccess("Reminder deleted.")
 }
 
-func completeReminderDelete(ctx router.AutocompleteCtx) {
+func completeReminder(ctx router.AutocompleteCtx) {
 	reminders, err := db.Reminders.GetAllByUser(ctx.Interaction.SenderID())
 	if err != nil {
 		log.Println(err)
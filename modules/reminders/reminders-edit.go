@@ -0,0 +1,102 @@
+package reminders
+
+import (
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var remindersEditCommand = &router.SubCommand{
+	Name:        "edit",
+	Description: "Change when a reminder is sent or what it reminds you of",
+	Handler: &router.CommandHandler{
+		Executor:      remindersEditExec,
+		Autocompleter: completeReminder,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:   "reminder",
+			Description:  "The reminder to edit",
+			Required:     true,
+			Autocomplete: true,
+		},
+		&discord.StringOption{
+			OptionName: "time",
+			Description: "When to send the reminder, e.g. 3 hours, " +
+				"tomorrow 9am, next friday",
+			MaxLength: option.NewInt(64),
+		},
+		&discord.StringOption{
+			OptionName:  "content",
+			Description: "What to be reminded of",
+			MaxLength:   option.NewInt(2048),
+		},
+	},
+}
+
+func remindersEditExec(ctx router.CommandCtx) {
+	reminderID, _ := ctx.Options.Find("reminder").IntValue()
+	timeString := ctx.Options.Find("time").String()
+	content := ctx.Options.Find("content").String()
+
+	if timeString == "" && content == "" {
+		ctx.RespondWarning("Please provide a new time or content to edit.")
+		return
+	}
+
+	var newTime time.Time
+	if timeString != "" {
+		var cerr router.CmdResponse
+		startTime := time.Now().In(userLocation(ctx.Interaction.SenderID()))
+		newTime, cerr = parseReminderTime(timeString, startTime)
+		if cerr != nil {
+			ctx.RespondCmdMessage(cerr)
+			return
+		}
+	}
+
+	if content != "" {
+		ok, err := db.Reminders.SetContent(
+			ctx.Interaction.SenderID(), int32(reminderID), content,
+		)
+		if err != nil {
+			log.Println(err)
+			ctx.RespondError("Error occurred while editing the reminder.")
+			return
+		}
+		if !ok {
+			ctx.RespondWarning("I could not find this reminder.")
+			return
+		}
+	}
+
+	if newTime.IsZero() {
+		ctx.RespondSuccess("Reminder edited.")
+		return
+	}
+
+	ok, err := db.Reminders.SetTime(
+		ctx.Interaction.SenderID(), int32(reminderID), newTime,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while editing the reminder.")
+		return
+	}
+	if !ok {
+		ctx.RespondWarning("I could not find this reminder.")
+		return
+	}
+
+	scheduler.schedule(int32(reminderID), newTime)
+
+	ctx.RespondSuccessf(
+		"Reminder edited. It will now be sent on %s.",
+		dctools.TimestampStyled(newTime, dctools.LongDateTime),
+	)
+}
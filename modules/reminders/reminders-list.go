@@ -34,17 +34,53 @@ func remindersListExec(ctx router.CommandCtx) {
 		return
 	}
 
+	respondReminderList(ctx, "Pending Reminders", reminders)
+}
+
+// respondReminderList responds with a paged list of reminders, sorted by the
+// time they are due.
+func respondReminderList(
+	ctx router.CommandCtx, title string, reminders []reminderdb.Reminder) {
+
 	slices.SortFunc(reminders, func(a, b reminderdb.Reminder) int {
 		return int(a.Time.Unix() - b.Time.Unix())
 	})
 
+	loc := userLocation(ctx.Interaction.SenderID())
+
 	lines := make([]string, len(reminders))
 	for i, r := range reminders {
 		lines[i] = fmt.Sprintf(
-			"- %s - %s",
+			"- %s (%s) - %s",
+			localTimeString(r.Time, loc),
 			dctools.TimestampStyled(r.Time, dctools.RelativeTime),
 			r.Content,
 		)
+		if r.MessageLink != "" {
+			lines[i] += "\n  - 🔗 " +
+				dctools.Hyperlink("Jump to Message", r.MessageLink)
+		}
+		if r.IsChannel() {
+			lines[i] += "\n  - 📢 Posted in " + r.ChannelID.Mention()
+			if r.RoleID.IsValid() {
+				lines[i] += " for " + r.RoleID.Mention()
+			}
+		}
+		if r.IsRecurring() {
+			lines[i] += "\n  - 🔁 Repeats " + describeSchedule(r.Schedule)
+		}
+		if r.Failed {
+			lines[i] += fmt.Sprintf(
+				"\n  - ⚠️ Could not be sent after %s",
+				util.PluraliseWithCount("attempt", int64(r.Attempts)),
+			)
+		} else if r.NextAttempt != nil {
+			lines[i] += fmt.Sprintf(
+				"\n  - 🔄 Failed to send %s, retrying %s",
+				util.PluraliseWithCount("time", int64(r.Attempts)),
+				dctools.TimestampStyled(*r.NextAttempt, dctools.RelativeTime),
+			)
+		}
 	}
 
 	descriptionPages := util.PagedLines(lines, 2048, 10)
@@ -56,7 +92,7 @@ func remindersListExec(ctx router.CommandCtx) {
 		pages[i] = router.MessagePage{
 			Embeds: []discord.Embed{
 				{
-					Title:       "Pending Reminders",
+					Title:       title,
 					Description: description,
 					Color:       dctools.EmbedBackColour,
 					Footer: &discord.EmbedFooter{
@@ -0,0 +1,202 @@
+package reminders
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/reminderdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const maxReminderOccurrences = 1000
+
+// scheduleOptions are the options used to set up how a reminder repeats.
+var scheduleOptions = []discord.CommandOptionValue{
+	&discord.StringOption{
+		OptionName: "repeat",
+		Description: "How often to repeat, e.g. daily, every 2 weeks, " +
+			"2nd tuesday of the month, 0 9 * * 1-5",
+		MaxLength: option.NewInt(128),
+	},
+	&discord.StringOption{
+		OptionName:  "until",
+		Description: "When to stop repeating, e.g. 2025-12-31 or 3 months",
+		MaxLength:   option.NewInt(64),
+	},
+	&discord.IntegerOption{
+		OptionName:  "times",
+		Description: "How many times to send the reminder in total",
+		Min:         option.NewInt(1),
+		Max:         option.NewInt(maxReminderOccurrences),
+	},
+}
+
+var remindersRepeatCommand = &router.SubCommand{
+	Name:        "repeat",
+	Description: "Set how a reminder repeats, or stop it from repeating",
+	Handler: &router.CommandHandler{
+		Executor:      remindersRepeatExec,
+		Autocompleter: completeReminder,
+		Ephemeral:     true,
+	},
+	Options: append(
+		[]discord.CommandOptionValue{
+			&discord.IntegerOption{
+				OptionName:   "reminder",
+				Description:  "The reminder to repeat",
+				Required:     true,
+				Autocomplete: true,
+			},
+		},
+		scheduleOptions...,
+	),
+}
+
+func remindersRepeatExec(ctx router.CommandCtx) {
+	reminderID, _ := ctx.Options.Find("reminder").IntValue()
+
+	start := time.Now().In(userLocation(ctx.Interaction.SenderID()))
+	schedule, cerr := parseScheduleOptions(ctx, start)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	ok, err := db.Reminders.SetSchedule(
+		ctx.Interaction.SenderID(), int32(reminderID), schedule,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while updating the reminder.")
+		return
+	}
+	if !ok {
+		ctx.RespondWarning("I could not find this reminder.")
+		return
+	}
+
+	if !schedule.IsRecurring() {
+		ctx.RespondSuccess("Reminder will no longer repeat.")
+		return
+	}
+
+	ctx.RespondSuccess(
+		"Reminder will now repeat " + describeSchedule(schedule) + ".",
+	)
+}
+
+// parseScheduleOptions parses the repeat, until and times options into a
+// reminder schedule. An empty repeat option results in a schedule that does
+// not repeat.
+func parseScheduleOptions(
+	ctx router.CommandCtx,
+	start time.Time) (reminderdb.Schedule, router.CmdResponse) {
+
+	var schedule reminderdb.Schedule
+
+	expression := strings.ToLower(
+		strings.TrimSpace(ctx.Options.Find("repeat").String()),
+	)
+	if expression == "" {
+		return schedule, nil
+	}
+
+	_, err := parseSchedule(expression)
+	if errors.Is(err, errScheduleTooShort) {
+		return schedule, router.Warningf(
+			"Reminders cannot repeat more often than every %s.",
+			util.PluraliseWithCount("minute", int64(minSchedulePeriod.Minutes())),
+		)
+	}
+	if err != nil {
+		return schedule, router.Warning(
+			"Invalid repeat schedule given. Example formats: `daily`, " +
+				"`every 2 weeks`, `every mon and fri`, " +
+				"`2nd tuesday of the month`, " +
+				"`0 9 * * 1-5`",
+		)
+	}
+
+	schedule.Expression = expression
+
+	until := ctx.Options.Find("until").String()
+	if until != "" {
+		endTime, ok := parseEndTime(until, start)
+		if !ok {
+			return schedule, router.Warning(
+				"Invalid end given. Example formats: `2025-12-31`, `3 months`",
+			)
+		}
+		if !endTime.After(start) {
+			return schedule, router.Warning("End must be in the future.")
+		}
+
+		schedule.EndTime = &endTime
+	}
+
+	times, _ := ctx.Options.Find("times").IntValue()
+	if times < 0 || times > maxReminderOccurrences {
+		return schedule, router.Warningf(
+			"Reminders cannot be sent more than %d times.",
+			maxReminderOccurrences,
+		)
+	}
+
+	schedule.Remaining = int32(times)
+
+	return schedule, nil
+}
+
+// parseEndTime parses either a date, an absolute time or a time period from
+// the start time.
+func parseEndTime(until string, start time.Time) (time.Time, bool) {
+	date, err := time.ParseInLocation(time.DateOnly, until, start.Location())
+	if err == nil {
+		return date.AddDate(0, 0, 1).Add(-time.Second), true
+	}
+
+	dateTime, ok := util.ParseDateTime(until, start)
+	if ok {
+		return dateTime, true
+	}
+
+	period := util.ParseTimePeriod(until)
+	if period.IsNull() {
+		return time.Time{}, false
+	}
+
+	return period.AfterTime(start), true
+}
+
+// describeSchedule returns a human readable description of how a reminder
+// repeats.
+func describeSchedule(schedule reminderdb.Schedule) string {
+	description := "`" + dctools.EscapeMarkdown(schedule.Expression) + "`"
+
+	// "every 2nd tuesday" could be read as every other Tuesday, so the month
+	// is made explicit.
+	expression := strings.ToLower(schedule.Expression)
+	sched, err := parseSchedule(expression)
+	nth, ok := sched.(nthWeekdaySchedule)
+	if err == nil && ok && !strings.Contains(expression, "month") {
+		description += " (" + nth.String() + ")"
+	}
+
+	if schedule.EndTime != nil {
+		description += " until " +
+			dctools.TimestampStyled(*schedule.EndTime, dctools.ShortDate)
+	}
+	if schedule.Remaining > 0 {
+		description += ", " +
+			util.PluraliseWithCount("time", int64(schedule.Remaining)) +
+			" remaining"
+	}
+
+	return description
+}
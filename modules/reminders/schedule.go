@@ -0,0 +1,406 @@
+package reminders
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+// minSchedulePeriod is the shortest period a reminder can repeat at.
+const minSchedulePeriod = 15 * time.Minute
+
+var (
+	errInvalidSchedule  = errors.New("invalid schedule")
+	errScheduleTooShort = errors.New("schedule repeats too often")
+)
+
+var (
+	nthWeekdayRegex = regexp.MustCompile(
+		`^(1st|2nd|3rd|4th|5th|first|second|third|fourth|fifth|last)\s+(\w+)` +
+			`(?:\s+of\s+(?:the|every|each)\s+month)?$`,
+	)
+	cronFieldRegex  = regexp.MustCompile(`^[\d*,/-]+$`)
+	listSplitRegex  = regexp.MustCompile(`\s*(?:,|\band\b|&)\s*|\s+`)
+	scheduleAliases = map[string]string{
+		"hourly":      "1 hour",
+		"daily":       "1 day",
+		"weekly":      "1 week",
+		"fortnightly": "2 weeks",
+		"monthly":     "1 month",
+		"yearly":      "1 year",
+		"annually":    "1 year",
+		"day":         "1 day",
+		"week":        "1 week",
+		"month":       "1 month",
+		"year":        "1 year",
+		"weekday":     "mon tue wed thu fri",
+		"weekdays":    "mon tue wed thu fri",
+		"weekend":     "sat sun",
+		"weekends":    "sat sun",
+	}
+	ordinals = map[string]int{
+		"1st": 1, "first": 1,
+		"2nd": 2, "second": 2,
+		"3rd": 3, "third": 3,
+		"4th": 4, "fourth": 4,
+		"5th": 5, "fifth": 5,
+		"last": -1,
+	}
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thur": time.Thursday,
+		"thurs": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+)
+
+// schedule calculates the times a recurring reminder repeats at.
+type schedule interface {
+	// next returns the first time in the schedule after the provided time,
+	// or the zero time if there is none.
+	next(after time.Time) time.Time
+}
+
+// parseSchedule parses a schedule expression such as "daily",
+// "every 3 days", "every mon and fri", "2nd tuesday of the month" or a cron
+// expression like "0 9 * * 1-5". "Of the month" may be left out of nth weekday
+// expressions, as in "every 2nd tuesday".
+func parseSchedule(expression string) (schedule, error) {
+	expression = strings.ToLower(strings.TrimSpace(expression))
+	if isCronExpression(expression) {
+		return parseCronSchedule(expression)
+	}
+
+	expression = strings.TrimPrefix(expression, "every ")
+	if alias, ok := scheduleAliases[expression]; ok {
+		expression = alias
+	}
+
+	if match := nthWeekdayRegex.FindStringSubmatch(expression); match != nil {
+		weekday, ok := parseWeekday(match[2])
+		if !ok {
+			return nil, errInvalidSchedule
+		}
+
+		return nthWeekdaySchedule{ordinals[match[1]], weekday}, nil
+	}
+
+	if days, ok := parseWeekdays(expression); ok {
+		return days, nil
+	}
+
+	period := util.ParseTimePeriod(expression)
+	if period.IsNull() {
+		return nil, errInvalidSchedule
+	}
+	if period.Duration() < minSchedulePeriod {
+		return nil, errScheduleTooShort
+	}
+
+	return periodSchedule{period}, nil
+}
+
+// nextOccurrence returns the first time in the schedule after now, skipping
+// any occurrences that were missed since the previous time.
+func nextOccurrence(s schedule, previous, now time.Time) time.Time {
+	next := s.next(previous)
+	for !next.IsZero() && !next.After(now) {
+		next = s.next(next)
+	}
+
+	return next
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	weekday, ok := weekdays[strings.TrimSuffix(name, "s")]
+	if !ok {
+		weekday, ok = weekdays[name]
+	}
+
+	return weekday, ok
+}
+
+func parseWeekdays(expression string) (weekdaySchedule, bool) {
+	var days weekdaySchedule
+
+	names := listSplitRegex.Split(expression, -1)
+	found := false
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			return days, false
+		}
+
+		days[weekday] = true
+		found = true
+	}
+
+	return days, found
+}
+
+// periodSchedule repeats after a fixed period of time.
+type periodSchedule struct {
+	period util.TimePeriod
+}
+
+func (s periodSchedule) next(after time.Time) time.Time {
+	return s.period.AfterTime(after)
+}
+
+// weekdaySchedule repeats on certain days of the week, at the same time of
+// day as the previous reminder.
+type weekdaySchedule [7]bool
+
+func (s weekdaySchedule) next(after time.Time) time.Time {
+	for i := 1; i <= 7; i++ {
+		t := after.AddDate(0, 0, i)
+		if s[t.Weekday()] {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// nthWeekdaySchedule repeats on the nth weekday of every month, such as
+// every 2nd Tuesday, at the same time of day as the previous reminder. An n of
+// -1 refers to the last weekday of the month.
+type nthWeekdaySchedule struct {
+	n       int
+	weekday time.Weekday
+}
+
+// String describes the schedule, e.g. the 2nd Tuesday of the month.
+func (s nthWeekdaySchedule) String() string {
+	nth := "last"
+	if s.n > 0 {
+		nth = humanize.Ordinal(s.n)
+	}
+
+	return fmt.Sprintf("the %s %s of the month", nth, s.weekday)
+}
+
+func (s nthWeekdaySchedule) next(after time.Time) time.Time {
+	year, month, _ := after.Date()
+	hour, min, sec := after.Clock()
+
+	for i := 0; i <= 12; i++ {
+		first := time.Date(
+			year, month+time.Month(i), 1, hour, min, sec, 0, after.Location(),
+		)
+
+		t, ok := s.inMonth(first)
+		if ok && t.After(after) {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s nthWeekdaySchedule) inMonth(first time.Time) (time.Time, bool) {
+	if s.n < 0 {
+		last := first.AddDate(0, 1, -1)
+		offset := (int(last.Weekday()) - int(s.weekday) + 7) % 7
+		return last.AddDate(0, 0, -offset), true
+	}
+
+	offset := (int(s.weekday) - int(first.Weekday()) + 7) % 7
+	t := first.AddDate(0, 0, offset+(s.n-1)*7)
+
+	return t, t.Month() == first.Month()
+}
+
+// cronField is a bitset of the values a cron field matches.
+type cronField uint64
+
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// cronSchedule repeats at times matching a standard 5-field cron expression:
+// minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	minutes     cronField
+	hours       cronField
+	daysOfMonth cronField
+	months      cronField
+	daysOfWeek  cronField
+	// if either day field is a wildcard, only the other needs to match.
+	// Otherwise, a day matching either field matches.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+func isCronExpression(expression string) bool {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return false
+	}
+
+	for _, field := range fields {
+		if !cronFieldRegex.MatchString(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseCronSchedule(expression string) (schedule, error) {
+	fields := strings.Fields(expression)
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+	var parsed [5]cronField
+	for i, field := range fields {
+		f, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = f
+	}
+
+	// 7 is an alias for Sunday.
+	if parsed[4].has(7) {
+		parsed[4] |= 1
+	}
+
+	s := cronSchedule{
+		minutes:       parsed[0],
+		hours:         parsed[1],
+		daysOfMonth:   parsed[2],
+		months:        parsed[3],
+		daysOfWeek:    parsed[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+
+	if s.minutes == 0 || s.hours == 0 || s.daysOfMonth == 0 ||
+		s.months == 0 || s.daysOfWeek == 0 {
+
+		return nil, errInvalidSchedule
+	}
+	if repeatsTooOften(s) {
+		return nil, errScheduleTooShort
+	}
+
+	return s, nil
+}
+
+// repeatsTooOften returns whether any of the next occurrences of a schedule
+// are closer together than the minimum schedule period.
+func repeatsTooOften(s schedule) bool {
+	previous := s.next(time.Now())
+	for i := 0; i < 100 && !previous.IsZero(); i++ {
+		next := s.next(previous)
+		if !next.IsZero() && next.Sub(previous) < minSchedulePeriod {
+			return true
+		}
+		previous = next
+	}
+
+	return false
+}
+
+func parseCronField(field string, min, max int) (cronField, error) {
+	var f cronField
+
+	for _, item := range strings.Split(field, ",") {
+		rangeString, stepString, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepString)
+			if err != nil || step < 1 {
+				return 0, errInvalidSchedule
+			}
+		}
+
+		start, end := min, max
+		if rangeString != "*" {
+			startString, endString, isRange := strings.Cut(rangeString, "-")
+
+			var err error
+			start, err = strconv.Atoi(startString)
+			if err != nil {
+				return 0, errInvalidSchedule
+			}
+
+			end = start
+			if isRange {
+				end, err = strconv.Atoi(endString)
+				if err != nil {
+					return 0, errInvalidSchedule
+				}
+			} else if hasStep {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, errInvalidSchedule
+		}
+
+		for i := start; i <= end; i += step {
+			f |= 1 << uint(i)
+		}
+	}
+
+	return f, nil
+}
+
+func (s cronSchedule) next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	loc := t.Location()
+
+	for t.Before(limit) {
+		year, month, day := t.Date()
+
+		if !s.months.has(int(month)) || !s.dayMatches(t) {
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.hours.has(t.Hour()) {
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !s.minutes.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.daysOfMonth.has(t.Day())
+	dowMatch := s.daysOfWeek.has(int(t.Weekday()))
+
+	switch {
+	case s.anyDayOfMonth:
+		return dowMatch
+	case s.anyDayOfWeek:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
@@ -0,0 +1,188 @@
+package reminders
+
+import (
+	"container/heap"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/state"
+)
+
+// reloadInterval is how often upcoming reminders are reloaded from the
+// database, picking up any reminders the scheduler was not notified of.
+const reloadInterval = time.Hour
+
+var scheduler = newReminderScheduler()
+
+// scheduledReminder is a reminder waiting in the scheduler queue.
+type scheduledReminder struct {
+	id    int32
+	time  time.Time
+	index int
+}
+
+// reminderQueue is a min-heap of scheduled reminders ordered by due time.
+type reminderQueue []*scheduledReminder
+
+func (q reminderQueue) Len() int { return len(q) }
+
+func (q reminderQueue) Less(i, j int) bool {
+	return q[i].time.Before(q[j].time)
+}
+
+func (q reminderQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *reminderQueue) Push(x any) {
+	r := x.(*scheduledReminder)
+	r.index = len(*q)
+	*q = append(*q, r)
+}
+
+func (q *reminderQueue) Pop() any {
+	old := *q
+	n := len(old)
+	r := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return r
+}
+
+// reminderScheduler wakes at the exact time reminders are due and sends all
+// reminders due at that time.
+type reminderScheduler struct {
+	mu      sync.Mutex
+	queue   reminderQueue
+	entries map[int32]*scheduledReminder
+	wake    chan struct{}
+	started sync.Once
+}
+
+func newReminderScheduler() *reminderScheduler {
+	return &reminderScheduler{
+		entries: make(map[int32]*scheduledReminder),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// start loads upcoming reminders and begins sending reminders as they become
+// due. Subsequent calls do nothing.
+func (s *reminderScheduler) start(st *state.State) {
+	s.started.Do(func() {
+		s.load()
+		go s.run(st)
+	})
+}
+
+// schedule queues a reminder to be sent at the provided time, replacing the
+// time it was previously queued for.
+func (s *reminderScheduler) schedule(id int32, t time.Time) {
+	s.mu.Lock()
+	if r, ok := s.entries[id]; ok {
+		r.time = t
+		heap.Fix(&s.queue, r.index)
+	} else {
+		r := &scheduledReminder{id: id, time: t}
+		heap.Push(&s.queue, r)
+		s.entries[id] = r
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// cancel removes a reminder from the queue.
+func (s *reminderScheduler) cancel(id int32) {
+	s.mu.Lock()
+	if r, ok := s.entries[id]; ok {
+		heap.Remove(&s.queue, r.index)
+		delete(s.entries, id)
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// notify wakes the scheduler so that it recalculates when it is next due.
+func (s *reminderScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the time the next reminder in the queue is due.
+func (s *reminderScheduler) next() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		return time.Time{}, false
+	}
+
+	return s.queue[0].time, true
+}
+
+// popDue removes all reminders due at or before now from the queue, and
+// returns the number of reminders removed.
+func (s *reminderScheduler) popDue(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var popped int
+	for len(s.queue) > 0 && !s.queue[0].time.After(now) {
+		r := heap.Pop(&s.queue).(*scheduledReminder)
+		delete(s.entries, r.id)
+		popped++
+	}
+
+	return popped
+}
+
+// load queues all reminders due before the next reload, including reminders
+// that were missed while the bot was offline.
+func (s *reminderScheduler) load() {
+	reminders, err := db.Reminders.GetDueBefore(
+		time.Now().Add(reloadInterval * 2),
+	)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, r := range reminders {
+		s.schedule(r.ID, r.DueTime())
+	}
+
+	log.Printf("Scheduled %d upcoming reminders\n", len(reminders))
+}
+
+func (s *reminderScheduler) run(st *state.State) {
+	reload := time.NewTicker(reloadInterval)
+	defer reload.Stop()
+
+	for {
+		wait := reloadInterval
+		if next, ok := s.next(); ok {
+			wait = time.Until(next)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		case <-reload.C:
+			timer.Stop()
+			s.load()
+		}
+
+		if s.popDue(time.Now()) > 0 {
+			sendDueReminders(st)
+		}
+	}
+}
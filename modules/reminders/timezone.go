@@ -0,0 +1,35 @@
+package reminders
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+// userLocation returns the location of the timezone set by a user, or UTC if
+// they have not set one.
+func userLocation(userID discord.UserID) *time.Location {
+	timezone, err := db.Users.GetTimezone(userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Println(err)
+		}
+		return time.UTC
+	}
+
+	loc, ok := util.LoadTimeZone(timezone)
+	if !ok {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// localTimeString formats a time in the provided location.
+func localTimeString(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("Mon 2 Jan 2006 15:04 MST")
+}
@@ -0,0 +1,15 @@
+package settings
+
+import (
+	"github.com/twoscott/haseul-bot-2/database"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var db *database.DB
+
+func Init(rt *router.Router) {
+	db = database.GetInstance()
+
+	rt.AddCommand(settingsCommand)
+	settingsCommand.AddSubCommand(settingsTimezoneCommand)
+}
@@ -0,0 +1,116 @@
+package settings
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var settingsTimezoneCommand = &router.SubCommand{
+	Name:        "timezone",
+	Description: "Set the timezone used to interpret and display times for you",
+	Handler: &router.CommandHandler{
+		Executor:      settingsTimezoneExec,
+		Autocompleter: completeTimezone,
+		Ephemeral:     true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:   "timezone",
+			Description:  "Your timezone, e.g. Europe/London",
+			MaxLength:    option.NewInt(64),
+			Autocomplete: true,
+		},
+	},
+}
+
+func settingsTimezoneExec(ctx router.CommandCtx) {
+	name := ctx.Options.Find("timezone").String()
+	if name == "" {
+		showTimezone(ctx)
+		return
+	}
+
+	loc, ok := util.LoadTimeZone(name)
+	if !ok {
+		ctx.RespondWarning(
+			"Invalid timezone given. Please choose a timezone from the list, " +
+				"e.g. `Europe/London`.",
+		)
+		return
+	}
+
+	err := db.Users.SetTimezone(ctx.Interaction.SenderID(), loc.String())
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting your timezone.")
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"Your timezone has been set to %s. Your local time is %s.",
+		dctools.Bold(loc.String()),
+		time.Now().In(loc).Format("15:04 MST"),
+	)
+}
+
+func showTimezone(ctx router.CommandCtx) {
+	timezone, err := db.Users.GetTimezone(ctx.Interaction.SenderID())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching your timezone.")
+		return
+	}
+	if timezone == "" {
+		ctx.RespondWarning(
+			"You have not set a timezone, so times are interpreted as UTC.",
+		)
+		return
+	}
+
+	ctx.RespondSuccessf("Your timezone is %s.", dctools.Bold(timezone))
+}
+
+func completeTimezone(ctx router.AutocompleteCtx) {
+	query := strings.ToLower(ctx.Options.Find("timezone").String())
+
+	timezones := make(map[string]string, len(util.TimeZones))
+	names := make([]string, 0, len(util.TimeZones))
+	for _, tz := range util.TimeZones {
+		name := strings.ToLower(tz)
+		timezones[name] = tz
+		names = append(names, name)
+	}
+
+	matches := util.SearchSort(names, query)
+	if len(matches) > dctools.MaxChoices {
+		matches = matches[:dctools.MaxChoices]
+	}
+
+	now := time.Now()
+	choices := make(api.AutocompleteStringChoices, 0, len(matches))
+	for _, m := range matches {
+		tz := timezones[m]
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			continue
+		}
+
+		choice := discord.StringChoice{
+			Name:  tz + " (" + now.In(loc).Format("UTC-07:00") + ")",
+			Value: tz,
+		}
+		choices = append(choices, choice)
+	}
+
+	ctx.RespondChoices(choices)
+}
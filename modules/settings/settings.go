@@ -0,0 +1,10 @@
+package settings
+
+import (
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var settingsCommand = &router.Command{
+	Name:        "settings",
+	Description: "Commands pertaining to your personal settings",
+}
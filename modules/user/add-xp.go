@@ -2,16 +2,40 @@ package user
 
 import (
 	"log"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
 	"github.com/twoscott/haseul-bot-2/router"
 )
 
 const baseXp int64 = 5
 
 func addXP(rt *router.Router, msg discord.Message, member *discord.Member) {
+	levelConfig, err := levelConfigs.Config(msg.GuildID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	channelIDs := []discord.ChannelID{msg.ChannelID}
+	channel, err := rt.State.Channel(msg.ChannelID)
+	if err == nil && channel.ParentID.IsValid() {
+		channelIDs = append(channelIDs, channel.ParentID)
+	}
+
+	var roleIDs []discord.RoleID
+	if member != nil {
+		roleIDs = member.RoleIDs
+	}
+
+	multiplier := levelConfig.Multiplier(channelIDs, roleIDs)
+	if multiplier <= 0 {
+		return
+	}
+
 	xp := baseXp
 
 	words := strings.Fields(msg.Content)
@@ -38,13 +62,45 @@ func addXP(rt *router.Router, msg discord.Message, member *discord.Member) {
 
 	if recentMessage != nil {
 		sentTime := recentMessage.Timestamp.Time()
-		if time.Since(sentTime) < (15 * time.Second) {
+		if time.Since(sentTime) < levelConfig.config.Cooldown() {
 			xp /= 5
 		}
 	}
 
-	_, err := db.Levels.AddUserXP(msg.GuildID, msg.Author.ID, xp)
+	if isWeekend(time.Now()) {
+		multiplier *= levelConfig.config.WeekendMultiplier
+	}
+
+	xp = int64(math.Round(float64(xp) * multiplier))
+	if xp <= 0 {
+		return
+	}
+
+	totalXP, err := db.Levels.AddUserXP(msg.GuildID, msg.Author.ID, xp)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+
+	curve := levelConfig.config.Curve()
+	oldLevel := levelsdb.UserXP{XP: totalXP - xp}.Level(curve)
+	newLevel := levelsdb.UserXP{XP: totalXP}.Level(curve)
+	if newLevel > oldLevel {
+		handleLevelUp(
+			rt,
+			msg.GuildID,
+			msg.ChannelID,
+			msg.Author,
+			member,
+			levelConfig.config,
+			newLevel,
+		)
+	}
+}
+
+// isWeekend returns whether the provided time falls on a Saturday or Sunday
+// in UTC.
+func isWeekend(t time.Time) bool {
+	weekday := t.UTC().Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
 }
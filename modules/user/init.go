@@ -1,19 +1,67 @@
 package user
 
 import (
+	"time"
+
 	"github.com/twoscott/haseul-bot-2/database"
 	"github.com/twoscott/haseul-bot-2/router"
 )
 
-var db *database.DB
+const maxLevelConfigAge = 10 * time.Minute
+
+var (
+	db               *database.DB
+	levelConfigs     *levelConfigCache
+	voiceSessions    *voiceTracker
+	repResetLocation *time.Location
+)
 
 func Init(rt *router.Router) {
 	db = database.GetInstance()
+	levelConfigs = newLevelConfigCache(maxLevelConfigAge)
+	go levelConfigs.ClearJob(time.Hour)
+	voiceSessions = newVoiceTracker()
+	repResetLocation = loadRepResetLocation()
 
 	rt.AddMessageHandler(addXP)
+	rt.AddVoiceStateHandler(trackVoiceState)
+	rt.AddStartupListener(startVoiceTracking)
+	rt.AddStartupListener(startLeaderboardResets)
+	rt.AddStartupListener(startRepStreakChecks)
 
 	rt.AddCommand(levelsCommand)
 	levelsCommand.AddSubCommand(levelsLeaderboardCommand)
+	levelsCommand.AddSubCommand(levelsRankCommand)
+
+	levelsCommand.AddSubCommandGroup(levelsAnnouncementsCommand)
+	levelsAnnouncementsCommand.AddSubCommand(levelsAnnouncementsChannelCommand)
+	levelsAnnouncementsCommand.AddSubCommand(levelsAnnouncementsMessageCommand)
+	levelsAnnouncementsCommand.AddSubCommand(levelsAnnouncementsDisableCommand)
+	levelsAnnouncementsCommand.AddSubCommand(
+		levelsAnnouncementsLeaderboardCommand,
+	)
+
+	levelsCommand.AddSubCommandGroup(levelsRewardsCommand)
+	levelsRewardsCommand.AddSubCommand(levelsRewardsAddCommand)
+	levelsRewardsCommand.AddSubCommand(levelsRewardsRemoveCommand)
+	levelsRewardsCommand.AddSubCommand(levelsRewardsListCommand)
+	levelsRewardsCommand.AddSubCommand(levelsRewardsModeCommand)
+
+	levelsCommand.AddSubCommandGroup(levelsXPCommand)
+	levelsXPCommand.AddSubCommand(levelsXPChannelCommand)
+	levelsXPCommand.AddSubCommand(levelsXPRoleCommand)
+	levelsXPCommand.AddSubCommand(levelsXPRemoveCommand)
+	levelsXPCommand.AddSubCommand(levelsXPCooldownCommand)
+	levelsXPCommand.AddSubCommand(levelsXPWeekendCommand)
+	levelsXPCommand.AddSubCommand(levelsXPCurveCommand)
+	levelsXPCommand.AddSubCommand(levelsXPListCommand)
+
+	levelsCommand.AddSubCommandGroup(levelsAdminCommand)
+	levelsAdminCommand.AddSubCommand(levelsAdminSetCommand)
+	levelsAdminCommand.AddSubCommand(levelsAdminAddCommand)
+	levelsAdminCommand.AddSubCommand(levelsAdminRemoveCommand)
+	levelsAdminCommand.AddSubCommand(levelsAdminResetCommand)
+	levelsAdminCommand.AddSubCommand(levelsAdminImportCommand)
 
 	rt.AddCommand(marriageCommand)
 	marriageCommand.AddSubCommand(marriageDivorceCommand)
@@ -24,6 +72,7 @@ func Init(rt *router.Router) {
 	repCommand.AddSubCommand(repGiveCommand)
 	repCommand.AddSubCommand(repStatusCommand)
 	repCommand.AddSubCommand(repLeaderboardCommand)
+	repCommand.AddSubCommand(repHistoryCommand)
 
 	repCommand.AddSubCommandGroup(repStreaksCommand)
 	repStreaksCommand.AddSubCommand(repStreaksListCommand)
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+const (
+	allTimePeriod = iota
+	weeklyPeriod
+	monthlyPeriod
+)
+
+var leaderboardPeriodChoices = []discord.IntegerChoice{
+	{Name: "All Time", Value: allTimePeriod},
+	{Name: "Weekly", Value: weeklyPeriod},
+	{Name: "Monthly", Value: monthlyPeriod},
+}
+
+// weekStart returns the start of the week the provided time falls in, with
+// weeks starting on Monday in UTC.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(
+		t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC,
+	)
+}
+
+// monthStart returns the start of the month the provided time falls in, in
+// UTC.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// periodRange returns the start and end of the leaderboard period the
+// provided time falls in.
+func periodRange(period int64, t time.Time) (start, end time.Time) {
+	switch period {
+	case weeklyPeriod:
+		start = weekStart(t)
+		end = start.AddDate(0, 0, 7)
+	case monthlyPeriod:
+		start = monthStart(t)
+		end = start.AddDate(0, 1, 0)
+	}
+
+	return start, end
+}
+
+// periodName returns the display name of a leaderboard period.
+func periodName(period int64) string {
+	switch period {
+	case weeklyPeriod:
+		return "Weekly"
+	case monthlyPeriod:
+		return "Monthly"
+	default:
+		return "All Time"
+	}
+}
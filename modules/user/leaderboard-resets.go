@@ -0,0 +1,158 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+const (
+	leaderboardResetInterval = time.Hour
+	resetLeaderboardSize     = 10
+)
+
+var leaderboardResetsStarted sync.Once
+
+// startLeaderboardResets starts the leaderboard reset job in the background.
+// Subsequent calls on reconnect do nothing.
+func startLeaderboardResets(rt *router.Router, _ *gateway.ReadyEvent) {
+	leaderboardResetsStarted.Do(func() {
+		go leaderboardResetJob(rt)
+	})
+}
+
+func leaderboardResetJob(rt *router.Router) {
+	announceLeaderboardResets(rt)
+
+	ticker := time.NewTicker(leaderboardResetInterval)
+	for range ticker.C {
+		announceLeaderboardResets(rt)
+	}
+}
+
+// announceLeaderboardResets announces the top members of the previous week
+// and month in guilds that have a leaderboard channel set, once the weekly
+// and monthly leaderboards have reset.
+func announceLeaderboardResets(rt *router.Router) {
+	now := time.Now()
+	currentWeek := weekStart(now)
+	currentMonth := monthStart(now)
+
+	configs, err := db.Levels.GetLeaderboardResetConfigs()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, c := range configs {
+		announced := c.WeeklyResetAnnounced
+		if announced == nil || announced.Before(currentWeek) {
+			lastWeek := currentWeek.AddDate(0, 0, -7)
+			announceLeaderboardReset(rt, c, weeklyPeriod, lastWeek)
+
+			_, err := db.Levels.SetWeeklyResetAnnounced(c.GuildID, currentWeek)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+
+		announced = c.MonthlyResetAnnounced
+		if announced == nil || announced.Before(currentMonth) {
+			lastMonth := currentMonth.AddDate(0, -1, 0)
+			announceLeaderboardReset(rt, c, monthlyPeriod, lastMonth)
+
+			_, err := db.Levels.SetMonthlyResetAnnounced(
+				c.GuildID, currentMonth,
+			)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	// XP from before the previous month is no longer needed for any
+	// leaderboard or reset announcement.
+	deleted, err := db.Levels.DeleteXPBucketsBefore(
+		currentMonth.AddDate(0, -1, 0),
+	)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Deleted %d expired XP buckets\n", deleted)
+	}
+}
+
+// announceLeaderboardReset posts the top members of the period starting at
+// the provided time to a guild's leaderboard channel.
+func announceLeaderboardReset(
+	rt *router.Router,
+	config levelsdb.LevelConfig,
+	period int64,
+	start time.Time) {
+
+	_, end := periodRange(period, start)
+
+	usersXP, err := db.Levels.GetTopUsersBetween(
+		config.GuildID, start, end, resetLeaderboardSize,
+	)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(usersXP) < 1 {
+		return
+	}
+
+	var periodLabel, footer string
+	switch period {
+	case weeklyPeriod:
+		periodLabel = "week"
+		footer = "Week of " + start.Format("2 January 2006")
+	case monthlyPeriod:
+		periodLabel = "month"
+		footer = start.Format("January 2006")
+	}
+
+	listName := periodName(period)
+	guild, err := rt.State.Guild(config.GuildID)
+	if err == nil {
+		listName = guild.Name + " " + listName
+	}
+
+	rows := leaderboardRows(rt.State, usersXP, nil)
+
+	_, err = rt.State.SendMessageComplex(
+		config.LeaderboardChannelID,
+		api.SendMessageData{
+			Content: fmt.Sprintf(
+				"🏆 The %s leaderboard has reset! Here are last %s's top "+
+					"members.",
+				strings.ToLower(periodName(period)),
+				periodLabel,
+			),
+			Embeds: []discord.Embed{
+				{
+					Title:       listName + " Leaderboard",
+					Description: strings.Join(rows, "\n"),
+					Color:       dctools.EmbedBackColour,
+					Footer:      &discord.EmbedFooter{Text: footer},
+				},
+			},
+			AllowedMentions: dctools.NoMentions,
+		},
+	)
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,185 @@
+package user
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+)
+
+// guildLevelConfig is a guild's level config and XP rules, cached so that the
+// database isn't queried for every message sent.
+type guildLevelConfig struct {
+	loadedAt time.Time
+	config   levelsdb.LevelConfig
+	channels map[discord.ChannelID]float64
+	roles    map[discord.RoleID]float64
+}
+
+// Age returns how long ago the config was loaded from the database.
+func (c guildLevelConfig) Age() time.Duration {
+	return time.Since(c.loadedAt)
+}
+
+// Multiplier returns the XP multiplier for a message sent in the provided
+// channels by a member with the provided roles. Any channel or role with a
+// multiplier of 0 stops XP from being earned, otherwise the channel
+// multipliers are combined with the highest role multiplier.
+func (c guildLevelConfig) Multiplier(
+	channelIDs []discord.ChannelID, roleIDs []discord.RoleID) float64 {
+
+	multiplier := 1.0
+	for _, id := range channelIDs {
+		m, ok := c.channels[id]
+		if ok {
+			multiplier *= m
+		}
+	}
+
+	var (
+		roleMultiplier float64
+		hasRoleRule    bool
+	)
+	for _, id := range roleIDs {
+		m, ok := c.roles[id]
+		if !ok {
+			continue
+		}
+		if m == 0 {
+			return 0
+		}
+		if !hasRoleRule || m > roleMultiplier {
+			roleMultiplier = m
+			hasRoleRule = true
+		}
+	}
+
+	if hasRoleRule {
+		multiplier *= roleMultiplier
+	}
+
+	return multiplier
+}
+
+// levelCurve returns the level curve used by a guild, or the default level
+// curve for global levels if the guild ID is invalid.
+func levelCurve(guildID discord.GuildID) levelsdb.LevelCurve {
+	if !guildID.IsValid() {
+		return levelsdb.DefaultLevelCurve
+	}
+
+	levelConfig, err := levelConfigs.Config(guildID)
+	if err != nil {
+		log.Println(err)
+		return levelsdb.DefaultLevelCurve
+	}
+
+	return levelConfig.config.Curve()
+}
+
+type levelConfigCache struct {
+	mu      sync.Mutex
+	configs map[discord.GuildID]guildLevelConfig
+	maxAge  time.Duration
+}
+
+// Config returns the cached level config for a guild, loading it from the
+// database if it isn't cached or is older than the max age.
+func (c *levelConfigCache) Config(
+	guildID discord.GuildID) (*guildLevelConfig, error) {
+
+	c.mu.Lock()
+	cached, ok := c.configs[guildID]
+	c.mu.Unlock()
+	if ok && cached.Age() < c.maxAge {
+		return &cached, nil
+	}
+
+	loaded, err := loadLevelConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.configs[guildID] = *loaded
+	c.mu.Unlock()
+
+	return loaded, nil
+}
+
+// Invalidate removes a guild's level config from the cache so that it is
+// reloaded from the database the next time it is used.
+func (c *levelConfigCache) Invalidate(guildID discord.GuildID) {
+	c.mu.Lock()
+	delete(c.configs, guildID)
+	c.mu.Unlock()
+}
+
+// ClearCache clears any level configs older than the max age.
+func (c *levelConfigCache) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for guildID, config := range c.configs {
+		if config.Age() > c.maxAge {
+			delete(c.configs, guildID)
+			deleted++
+		}
+	}
+
+	log.Printf("Deleted %d level configs from the cache\n", deleted)
+}
+
+// ClearJob starts a job that clears the cache at the provided interval.
+func (c *levelConfigCache) ClearJob(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		c.ClearCache()
+	}
+}
+
+func newLevelConfigCache(maxAge time.Duration) *levelConfigCache {
+	return &levelConfigCache{
+		configs: make(map[discord.GuildID]guildLevelConfig),
+		maxAge:  maxAge,
+	}
+}
+
+func loadLevelConfig(guildID discord.GuildID) (*guildLevelConfig, error) {
+	config, err := db.Levels.GetLevelConfig(guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		defaultConfig := levelsdb.DefaultLevelConfig(guildID)
+		config = &defaultConfig
+	} else if err != nil {
+		return nil, err
+	}
+
+	rules, err := db.Levels.GetXPRules(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := guildLevelConfig{
+		loadedAt: time.Now(),
+		config:   *config,
+		channels: make(map[discord.ChannelID]float64),
+		roles:    make(map[discord.RoleID]float64),
+	}
+
+	for _, r := range rules {
+		switch r.TargetType {
+		case levelsdb.ChannelXPRule:
+			loaded.channels[discord.ChannelID(r.TargetID)] = r.Multiplier
+		case levelsdb.RoleXPRule:
+			loaded.roles[discord.RoleID(r.TargetID)] = r.Multiplier
+		}
+	}
+
+	return &loaded, nil
+}
@@ -0,0 +1,144 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+// handleLevelUp announces a member reaching a new level, if the server has
+// level up announcements enabled, and gives the member any role rewards for
+// the level.
+func handleLevelUp(
+	rt *router.Router,
+	guildID discord.GuildID,
+	channelID discord.ChannelID,
+	user discord.User,
+	member *discord.Member,
+	config levelsdb.LevelConfig,
+	level int) {
+
+	if member == nil {
+		var err error
+		member, err = rt.State.Member(guildID, user.ID)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	member.User = user
+
+	if config.Announce {
+		announceLevelUp(rt, guildID, channelID, *member, config, level)
+	}
+
+	applyRoleRewards(rt, guildID, *member, config, level)
+}
+
+// announceLevelUp announces a member reaching a new level in the server's
+// announcement channel, or the channel the member levelled up in if the
+// server has no announcement channel set.
+func announceLevelUp(
+	rt *router.Router,
+	guildID discord.GuildID,
+	channelID discord.ChannelID,
+	member discord.Member,
+	config levelsdb.LevelConfig,
+	level int) {
+
+	guild, err := rt.State.Guild(guildID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	if config.AnnounceChannelID.IsValid() {
+		channelID = config.AnnounceChannelID
+	}
+
+	_, err = rt.State.SendMessageComplex(channelID, api.SendMessageData{
+		Content: config.FormattedAnnounceMessage(member, *guild, level),
+		AllowedMentions: &api.AllowedMentions{
+			Users: []discord.UserID{member.User.ID},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// applyRoleRewards gives a member the role rewards for every level they have
+// reached. If the server does not stack role rewards, only the role rewards
+// for the highest level reached are kept, and the member's other role rewards
+// are removed.
+func applyRoleRewards(
+	rt *router.Router,
+	guildID discord.GuildID,
+	member discord.Member,
+	config levelsdb.LevelConfig,
+	level int) {
+
+	rewards, err := db.Levels.GetRoleRewards(guildID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(rewards) < 1 {
+		return
+	}
+
+	var topLevel int32
+	for _, r := range rewards {
+		if int(r.Level) <= level && r.Level > topLevel {
+			topLevel = r.Level
+		}
+	}
+	if topLevel == 0 {
+		return
+	}
+
+	wanted := make(map[discord.RoleID]bool, len(rewards))
+	for _, r := range rewards {
+		if int(r.Level) > level {
+			continue
+		}
+
+		wanted[r.RoleID] = config.StackRewards || r.Level == topLevel
+	}
+
+	changed := false
+	roleIDs := make([]discord.RoleID, 0, len(member.RoleIDs)+len(wanted))
+	for _, roleID := range member.RoleIDs {
+		keep, isReward := wanted[roleID]
+		if isReward {
+			delete(wanted, roleID)
+			if !keep {
+				changed = true
+				continue
+			}
+		}
+
+		roleIDs = append(roleIDs, roleID)
+	}
+
+	for roleID, give := range wanted {
+		if give {
+			roleIDs = append(roleIDs, roleID)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	err = rt.State.ModifyMember(guildID, member.User.ID, api.ModifyMemberData{
+		Roles: &roleIDs,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsAdminAddCommand = &router.SubCommand{
+	Name:        "add",
+	Description: "Adds XP to a member",
+	Handler: &router.CommandHandler{
+		Executor: levelsAdminAddExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The member to update the XP of",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "xp",
+			Description: "The amount of XP to add",
+			Required:    true,
+			Min:         option.NewInt(1),
+			Max:         option.NewInt(maxAdminXP),
+		},
+	},
+}
+
+func levelsAdminAddExec(ctx router.CommandCtx) {
+	adjustXP(ctx, 1)
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const maxXPImportFileSize = 8 << 20
+
+const (
+	importReplaceXP = iota
+	importAddXP
+)
+
+var levelsAdminImportCommand = &router.SubCommand{
+	Name: "import",
+	Description: "Imports member XP from a CSV or JSON export of another " +
+		"levelling bot",
+	Handler: &router.CommandHandler{
+		Executor: levelsAdminImportExec,
+		Defer:    true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.AttachmentOption{
+			OptionName:  "file",
+			Description: "The CSV or JSON file containing user IDs and XP",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "mode",
+			Description: "Whether to replace or add to members' current XP",
+			Choices: []discord.IntegerChoice{
+				{Name: "Replace", Value: importReplaceXP},
+				{Name: "Add", Value: importAddXP},
+			},
+		},
+	},
+}
+
+func levelsAdminImportExec(ctx router.CommandCtx) {
+	if !checkAdminPermission(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("file").SnowflakeValue()
+	attachmentID := discord.AttachmentID(snowflake)
+	attachment, ok := ctx.Command.Resolved.Attachments[attachmentID]
+	if !ok {
+		ctx.RespondWarning("Please provide a file to import.")
+		return
+	}
+	if attachment.Size > maxXPImportFileSize {
+		ctx.RespondWarning("Import files must be smaller than 8 MB.")
+		return
+	}
+
+	data, err := dctools.DownloadAttachment(attachment)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred downloading attachment.")
+		return
+	}
+
+	users, invalid, err := decodeXPImport(attachment.Filename, data)
+	if err != nil {
+		ctx.RespondWarning(
+			"Unable to read the file. Please provide a CSV or JSON file " +
+				"containing user IDs and XP.",
+		)
+		return
+	}
+
+	users = dedupeImportedUsers(users)
+	if len(users) < 1 {
+		ctx.RespondWarning("The file does not contain any valid user XP.")
+		return
+	}
+
+	mode, _ := ctx.Options.Find("mode").IntValue()
+	imported, err := db.Levels.ImportUserXP(
+		ctx.Interaction.GuildID, users, mode == importAddXP,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while importing user XP.")
+		return
+	}
+
+	respondXPImportResults(ctx, imported, invalid)
+}
+
+// dedupeImportedUsers removes duplicate users from imported user XP, keeping
+// the last entry for each user.
+func dedupeImportedUsers(users []levelsdb.UserXP) []levelsdb.UserXP {
+	indexes := make(map[discord.UserID]int, len(users))
+	deduped := make([]levelsdb.UserXP, 0, len(users))
+
+	for _, u := range users {
+		if i, ok := indexes[u.UserID]; ok {
+			deduped[i] = u
+			continue
+		}
+
+		indexes[u.UserID] = len(deduped)
+		deduped = append(deduped, u)
+	}
+
+	return deduped
+}
+
+func respondXPImportResults(
+	ctx router.CommandCtx, imported int64, invalid []string) {
+
+	summary := fmt.Sprintf(
+		"Imported XP for %s.", util.PluraliseWithCount("member", imported),
+	)
+	if len(invalid) < 1 {
+		ctx.RespondSuccess(summary)
+		return
+	}
+
+	lines := make([]string, 0, len(invalid)+1)
+	lines = append(lines, router.Success(summary).String())
+	for _, row := range invalid {
+		lines = append(lines, router.Error(row).String())
+	}
+
+	descriptionPages := util.PagedLines(lines, 2048, 15)
+	pages := make([]router.MessagePage, len(descriptionPages))
+	footer := util.PluraliseWithCount("Invalid Entry", int64(len(invalid)))
+
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Title:       "XP Import",
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(pageID, footer),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
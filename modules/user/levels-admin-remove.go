@@ -0,0 +1,33 @@
+package user
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsAdminRemoveCommand = &router.SubCommand{
+	Name:        "remove",
+	Description: "Removes XP from a member",
+	Handler: &router.CommandHandler{
+		Executor: levelsAdminRemoveExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The member to update the XP of",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "xp",
+			Description: "The amount of XP to remove",
+			Required:    true,
+			Min:         option.NewInt(1),
+			Max:         option.NewInt(maxAdminXP),
+		},
+	},
+}
+
+func levelsAdminRemoveExec(ctx router.CommandCtx) {
+	adjustXP(ctx, -1)
+}
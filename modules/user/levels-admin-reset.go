@@ -0,0 +1,165 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const (
+	buttonIDCancelReset = "CANCEL_RESET"
+	resetConfirmTimeout = 5 * time.Minute
+)
+
+var resetButtons = &discord.ActionRowComponent{
+	&discord.ButtonComponent{
+		Label:    "Cancel",
+		CustomID: buttonIDCancelReset,
+		Style:    discord.SecondaryButtonStyle(),
+	},
+	&discord.ButtonComponent{
+		Label:    "Reset",
+		CustomID: router.ButtonIDConfirm,
+		Style:    discord.DangerButtonStyle(),
+	},
+}
+
+var levelsAdminResetCommand = &router.SubCommand{
+	Name: "reset",
+	Description: "Resets the XP of a member, or every member in the server " +
+		"if no member is provided",
+	Handler: &router.CommandHandler{
+		Executor: levelsAdminResetExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The member to reset the XP of",
+		},
+	},
+}
+
+func levelsAdminResetExec(ctx router.CommandCtx) {
+	if !checkAdminPermission(ctx) {
+		return
+	}
+
+	userSnowflake, _ := ctx.Options.Find("user").SnowflakeValue()
+	userID := discord.UserID(userSnowflake)
+	if !userID.IsValid() {
+		confirmGuildXPReset(ctx)
+		return
+	}
+
+	reset, err := db.Levels.ResetUserXP(ctx.Interaction.GuildID, userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while resetting user XP.")
+		return
+	}
+	if !reset {
+		ctx.RespondWarningf("%s has no XP to reset.", userID.Mention())
+		return
+	}
+
+	ctx.RespondSuccessf("%s's XP has been reset.", userID.Mention())
+}
+
+// confirmGuildXPReset asks the user to confirm resetting the XP of every
+// member in the server, and resets it if they confirm.
+func confirmGuildXPReset(ctx router.CommandCtx) {
+	entries, err := db.Levels.GetEntriesSize(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking server XP.")
+		return
+	}
+	if entries < 1 {
+		ctx.RespondWarning("No members in this server have any XP to reset.")
+		return
+	}
+
+	ch, cancel := ctx.State.ChanFor(func(ev any) bool {
+		i, ok := ev.(*gateway.InteractionCreateEvent)
+		if !ok || i.Message == nil || i.Message.Interaction == nil {
+			return false
+		}
+
+		_, ok = i.Data.(*discord.ButtonInteraction)
+		return ok &&
+			i.Message.Interaction.ID == ctx.Interaction.ID &&
+			i.SenderID() == ctx.Interaction.SenderID()
+	})
+	defer cancel()
+
+	msg := fmt.Sprintf(
+		"Are you sure you want to reset the XP of %s? This cannot be undone.",
+		util.PluraliseWithCount("member", entries),
+	)
+
+	err = ctx.RespondMessage(api.InteractionResponseData{
+		Content:    option.NewNullableString(router.Warning(msg).String()),
+		Components: discord.ComponentsPtr(resetButtons),
+	})
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while resetting server XP.")
+		return
+	}
+
+	var (
+		press *discord.InteractionEvent
+		data  *discord.ButtonInteraction
+	)
+	select {
+	case ev := <-ch:
+		i := ev.(*gateway.InteractionCreateEvent)
+		press = &i.InteractionEvent
+		data = i.Data.(*discord.ButtonInteraction)
+	case <-time.After(resetConfirmTimeout):
+		disableResetButtons(ctx)
+		return
+	}
+
+	itx := &router.InteractionCtx{
+		Router:      ctx.Router,
+		Interaction: press,
+	}
+
+	disableResetButtons(ctx)
+
+	if data.CustomID != router.ButtonIDConfirm {
+		itx.RespondText("Server XP reset cancelled.")
+		return
+	}
+
+	reset, err := db.Levels.ResetGuildXP(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		itx.RespondError("Error occurred while resetting server XP.")
+		return
+	}
+
+	itx.RespondSuccessf(
+		"XP has been reset for %s.", util.PluraliseWithCount("member", reset),
+	)
+}
+
+func disableResetButtons(ctx router.CommandCtx) {
+	d := dctools.DisabledButtons(*resetButtons)
+	ctx.State.EditInteractionResponse(
+		ctx.Interaction.AppID,
+		ctx.Interaction.Token,
+		api.EditInteractionResponseData{
+			Components: discord.ComponentsPtr(&d),
+		},
+	)
+}
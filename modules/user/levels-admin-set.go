@@ -0,0 +1,55 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsAdminSetCommand = &router.SubCommand{
+	Name:        "set",
+	Description: "Sets the XP of a member",
+	Handler: &router.CommandHandler{
+		Executor: levelsAdminSetExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The member to set the XP of",
+			Required:    true,
+		},
+		&discord.IntegerOption{
+			OptionName:  "xp",
+			Description: "The XP to give the member",
+			Required:    true,
+			Min:         option.NewInt(0),
+			Max:         option.NewInt(maxAdminXP),
+		},
+	},
+}
+
+func levelsAdminSetExec(ctx router.CommandCtx) {
+	if !checkAdminPermission(ctx) {
+		return
+	}
+
+	userSnowflake, _ := ctx.Options.Find("user").SnowflakeValue()
+	userID := discord.UserID(userSnowflake)
+	if !userID.IsValid() {
+		ctx.RespondWarning("Malformed user ID provided.")
+		return
+	}
+
+	xp, _ := ctx.Options.Find("xp").IntValue()
+
+	_, err := db.Levels.SetUserXP(ctx.Interaction.GuildID, userID, xp)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting user XP.")
+		return
+	}
+
+	respondXPChanged(ctx, userID, xp)
+}
@@ -0,0 +1,84 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/dustin/go-humanize"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+const maxAdminXP = 1_000_000_000
+
+var levelsAdminCommand = &router.SubCommandGroup{
+	Name:        "admin",
+	Description: "Commands for managing the XP of members in the server",
+}
+
+// checkAdminPermission responds with a warning and returns false if the user
+// cannot manage the XP of members.
+func checkAdminPermission(ctx router.CommandCtx) bool {
+	return checkLevelsPermission(
+		ctx, discord.PermissionManageGuild, "Manage Server",
+	)
+}
+
+// respondXPChanged responds with a user's new XP and level, and gives the
+// user any role rewards for their new level.
+func respondXPChanged(ctx router.CommandCtx, userID discord.UserID, xp int64) {
+	levelConfig, err := levelConfigs.Config(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching level settings.")
+		return
+	}
+
+	level := levelsdb.UserXP{XP: xp}.Level(levelConfig.config.Curve())
+
+	member, err := ctx.State.Member(ctx.Interaction.GuildID, userID)
+	if err == nil {
+		applyRoleRewards(
+			ctx.Router,
+			ctx.Interaction.GuildID,
+			*member,
+			levelConfig.config,
+			level,
+		)
+	}
+
+	ctx.RespondSuccessf(
+		"%s now has %s XP (Lvl %s).",
+		userID.Mention(),
+		humanize.Comma(xp),
+		humanize.Comma(int64(level)),
+	)
+}
+
+// adjustXP adds or removes XP from a user, depending on the sign, and
+// responds with the user's new XP.
+func adjustXP(ctx router.CommandCtx, sign int64) {
+	if !checkAdminPermission(ctx) {
+		return
+	}
+
+	userSnowflake, _ := ctx.Options.Find("user").SnowflakeValue()
+	userID := discord.UserID(userSnowflake)
+	if !userID.IsValid() {
+		ctx.RespondWarning("Malformed user ID provided.")
+		return
+	}
+
+	amount, _ := ctx.Options.Find("xp").IntValue()
+
+	xp, err := db.Levels.AdjustUserXP(
+		ctx.Interaction.GuildID, userID, sign*amount,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while updating user XP.")
+		return
+	}
+
+	respondXPChanged(ctx, userID, xp)
+}
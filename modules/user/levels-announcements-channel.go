@@ -0,0 +1,65 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var levelsAnnouncementsChannelCommand = &router.SubCommand{
+	Name: "channel",
+	Description: "Enables level up announcements and sets the channel they " +
+		"are posted to",
+	Handler: &router.CommandHandler{
+		Executor: levelsAnnouncementsChannelExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.ChannelOption{
+			OptionName: "channel",
+			Description: "The channel to announce level ups in, or the " +
+				"channel members level up in if not provided",
+			ChannelTypes: dctools.TextChannelTypes(),
+		},
+	},
+}
+
+func levelsAnnouncementsChannelExec(ctx router.CommandCtx) {
+	if !checkAnnouncementsPermission(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("channel").SnowflakeValue()
+	channelID := discord.ChannelID(snowflake)
+
+	if channelID.IsValid() {
+		channel, cerr := ctx.ParseSendableChannel(channelID)
+		if cerr != nil {
+			ctx.RespondCmdMessage(cerr)
+			return
+		}
+
+		channelID = channel.ID
+	}
+
+	_, err := db.Levels.SetAnnounceChannel(ctx.Interaction.GuildID, channelID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting announcement channel.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	if channelID.IsValid() {
+		ctx.RespondSuccess(
+			"Level ups will now be announced in " + channelID.Mention() + ".",
+		)
+	} else {
+		ctx.RespondSuccess(
+			"Level ups will now be announced in the channel members " +
+				"level up in.",
+		)
+	}
+}
@@ -0,0 +1,37 @@
+package user
+
+import (
+	"log"
+
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsAnnouncementsDisableCommand = &router.SubCommand{
+	Name:        "disable",
+	Description: "Stops level ups from being announced in the server",
+	Handler: &router.CommandHandler{
+		Executor: levelsAnnouncementsDisableExec,
+	},
+}
+
+func levelsAnnouncementsDisableExec(ctx router.CommandCtx) {
+	if !checkAnnouncementsPermission(ctx) {
+		return
+	}
+
+	disabled, err := db.Levels.DisableAnnouncements(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while disabling announcements.")
+		return
+	}
+
+	if !disabled {
+		ctx.RespondWarning("Level up announcements are already disabled.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	ctx.RespondSuccess("Level up announcements disabled.")
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"log"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var levelsAnnouncementsLeaderboardCommand = &router.SubCommand{
+	Name: "leaderboard",
+	Description: "Sets the channel weekly and monthly leaderboard resets are " +
+		"announced in",
+	Handler: &router.CommandHandler{
+		Executor: levelsAnnouncementsLeaderboardExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.ChannelOption{
+			OptionName: "channel",
+			Description: "The channel to announce leaderboard resets in, or " +
+				"none to stop announcing resets",
+			ChannelTypes: dctools.TextChannelTypes(),
+		},
+	},
+}
+
+func levelsAnnouncementsLeaderboardExec(ctx router.CommandCtx) {
+	if !checkAnnouncementsPermission(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("channel").SnowflakeValue()
+	channelID := discord.ChannelID(snowflake)
+
+	if !channelID.IsValid() {
+		disabled, err := db.Levels.DisableLeaderboardResets(
+			ctx.Interaction.GuildID,
+		)
+		if err != nil {
+			log.Println(err)
+			ctx.RespondError("Error occurred while disabling announcements.")
+			return
+		}
+		if !disabled {
+			ctx.RespondWarning(
+				"Leaderboard reset announcements are already disabled.",
+			)
+			return
+		}
+
+		levelConfigs.Invalidate(ctx.Interaction.GuildID)
+		ctx.RespondSuccess("Leaderboard reset announcements disabled.")
+		return
+	}
+
+	channel, cerr := ctx.ParseSendableChannel(channelID)
+	if cerr != nil {
+		ctx.RespondCmdMessage(cerr)
+		return
+	}
+
+	now := time.Now()
+	_, err := db.Levels.SetLeaderboardChannel(
+		ctx.Interaction.GuildID, channel.ID, weekStart(now), monthStart(now),
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting announcement channel.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	ctx.RespondSuccess(
+		"Weekly and monthly leaderboard resets will now be announced in " +
+			channel.Mention() + ".",
+	)
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsAnnouncementsMessageCommand = &router.SubCommand{
+	Name: "message",
+	Description: "Edit the level up announcement message, using {user}, " +
+		"{username}, {level} and {server}",
+	Handler: &router.CommandHandler{
+		Executor: levelsAnnouncementsMessageExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.StringOption{
+			OptionName:  "message",
+			Description: "The message to announce level ups with",
+			Required:    true,
+			MaxLength:   option.NewInt(1024),
+		},
+	},
+}
+
+func levelsAnnouncementsMessageExec(ctx router.CommandCtx) {
+	if !checkAnnouncementsPermission(ctx) {
+		return
+	}
+
+	message := ctx.Options.Find("message").String()
+
+	_, err := db.Levels.SetAnnounceMessage(ctx.Interaction.GuildID, message)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting announcement message.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	ctx.RespondSuccess("Level up announcement message edited.")
+}
@@ -0,0 +1,19 @@
+package user
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsAnnouncementsCommand = &router.SubCommandGroup{
+	Name:        "announcements",
+	Description: "Commands pertaining to level up announcements",
+}
+
+// checkAnnouncementsPermission responds with a warning and returns false if
+// the user cannot manage level up announcements.
+func checkAnnouncementsPermission(ctx router.CommandCtx) bool {
+	return checkLevelsPermission(
+		ctx, discord.PermissionManageGuild, "Manage Server",
+	)
+}
@@ -1,10 +1,14 @@
 package user
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/dustin/go-humanize"
 	"github.com/twoscott/haseul-bot-2/database/levelsdb"
@@ -30,6 +34,11 @@ var levelsLeaderboardCommand = &router.SubCommand{
 				{Name: "Global", Value: globalScope},
 			},
 		},
+		&discord.IntegerOption{
+			OptionName:  "period",
+			Description: "The period of time to rank users by XP earned in",
+			Choices:     leaderboardPeriodChoices,
+		},
 		&discord.IntegerOption{
 			OptionName:  "users",
 			Description: "The amount of top users to list",
@@ -41,68 +50,74 @@ var levelsLeaderboardCommand = &router.SubCommand{
 
 func levelsLeaderboardExec(ctx router.CommandCtx) {
 	scope, _ := ctx.Options.Find("scope").IntValue()
+	period, _ := ctx.Options.Find("period").IntValue()
 	limit, _ := ctx.Options.Find("users").IntValue()
 	if limit == 0 {
 		limit = 10
 	}
 
-	var (
-		usersXP  []levelsdb.UserXP
-		err      error
-		listName string
-		entries  int64
-	)
+	var listName string
 	switch scope {
 	case serverScope:
-		var gUsers []levelsdb.GuildUserXP
-		gUsers, err = db.Levels.GetTopUsers(ctx.Interaction.GuildID, limit)
-		for _, gu := range gUsers {
-			usersXP = append(usersXP, gu.UserXP)
-		}
-
 		guild, err := ctx.State.Guild(ctx.Interaction.GuildID)
-		if err != nil {
-			break
+		if err == nil {
+			listName = guild.Name + " "
 		}
-
-		listName = guild.Name + " "
-		entries, _ = db.Levels.GetEntriesSize(guild.ID)
-
 	case globalScope:
-		usersXP, err = db.Levels.GetTopGlobalUsers(limit)
 		listName = "Global" + " "
-		entries, _ = db.Levels.GetGlobalEntriesSize()
 	}
+
+	if period != allTimePeriod {
+		listName += periodName(period) + " "
+	}
+
+	var guildID discord.GuildID
+	if scope == serverScope {
+		guildID = ctx.Interaction.GuildID
+	}
+
+	usersXP, entries, err := fetchLeaderboard(guildID, period, limit)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while fetching top users.")
 		return
 	}
+	if len(usersXP) < 1 {
+		ctx.RespondWarning("No users have earned XP in this period yet.")
+		return
+	}
 
-	userList := make([]string, 0, len(usersXP))
-	for i, uxp := range usersXP {
-		var username string
-		user, err := ctx.State.User(uxp.UserID)
-		if err != nil {
-			log.Println(err)
-			username = uxp.UserID.Mention()
-		} else {
-			username = dctools.EscapeMarkdown(user.Username)
-		}
-
-		row := fmt.Sprintf(
-			"%d. %s (Lvl %s) - %s XP",
-			i+1,
-			username,
-			humanize.Comma(int64(uxp.Level())),
-			humanize.Comma(uxp.XP),
-		)
-		userList = append(userList, row)
+	// levels are only shown for all time leaderboards, as XP earned in a
+	// period doesn't correspond to a level.
+	var curve levelsdb.LevelCurve
+	if period == allTimePeriod {
+		curve = levelCurve(guildID)
 	}
 
+	userList := leaderboardRows(ctx.State, usersXP, curve)
+
 	descriptionPages := util.PagedLines(userList, 2048, 25)
 	footer := util.PluraliseWithCount("Total Entry", entries)
 
+	rank, err := fetchLeaderboardRank(
+		guildID, ctx.Interaction.SenderID(), period,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+	}
+	if err == nil {
+		footer = dctools.SeparateEmbedFooter(
+			footer, "You: "+rankPosition(*rank),
+		)
+	}
+
+	if period != allTimePeriod {
+		_, end := periodRange(period, time.Now())
+		footer = dctools.SeparateEmbedFooter(
+			footer, "Resets "+end.Format("Mon 2 Jan")+" (UTC)",
+		)
+	}
+
 	pages := make([]router.MessagePage, len(descriptionPages))
 	for i, description := range descriptionPages {
 		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
@@ -125,3 +140,103 @@ func levelsLeaderboardExec(ctx router.CommandCtx) {
 
 	ctx.RespondPaging(pages)
 }
+
+// fetchLeaderboard returns the top users by XP in a guild, or globally if the
+// guild ID is invalid, along with the total number of users ranked. For
+// periods other than all time, users are ranked by the XP earned in the
+// current period.
+func fetchLeaderboard(
+	guildID discord.GuildID,
+	period int64,
+	limit int64) (usersXP []levelsdb.UserXP, entries int64, err error) {
+
+	if period != allTimePeriod {
+		start, end := periodRange(period, time.Now())
+		if !guildID.IsValid() {
+			usersXP, err = db.Levels.GetTopGlobalUsersBetween(start, end, limit)
+			entries, _ = db.Levels.GetGlobalEntriesSizeBetween(start, end)
+			return usersXP, entries, err
+		}
+
+		usersXP, err = db.Levels.GetTopUsersBetween(guildID, start, end, limit)
+		entries, _ = db.Levels.GetEntriesSizeBetween(guildID, start, end)
+		return usersXP, entries, err
+	}
+
+	if !guildID.IsValid() {
+		usersXP, err = db.Levels.GetTopGlobalUsers(limit)
+		entries, _ = db.Levels.GetGlobalEntriesSize()
+		return usersXP, entries, err
+	}
+
+	gUsers, err := db.Levels.GetTopUsers(guildID, limit)
+	for _, gu := range gUsers {
+		usersXP = append(usersXP, gu.UserXP)
+	}
+	entries, _ = db.Levels.GetEntriesSize(guildID)
+
+	return usersXP, entries, err
+}
+
+// fetchLeaderboardRank returns the rank position of a user on a leaderboard
+// in a guild, or globally if the guild ID is invalid. For periods other than
+// all time, users are ranked by the XP earned in the current period.
+func fetchLeaderboardRank(
+	guildID discord.GuildID,
+	userID discord.UserID,
+	period int64) (*levelsdb.UserRank, error) {
+
+	if period != allTimePeriod {
+		start, end := periodRange(period, time.Now())
+		if !guildID.IsValid() {
+			return db.Levels.GetUserGlobalRankBetween(userID, start, end)
+		}
+
+		return db.Levels.GetUserRankBetween(guildID, userID, start, end)
+	}
+
+	if !guildID.IsValid() {
+		return db.Levels.GetUserGlobalRank(userID)
+	}
+
+	return db.Levels.GetUserRank(guildID, userID)
+}
+
+// leaderboardRows returns the leaderboard rows for the provided users,
+// including their levels on the provided level curve if it is not nil.
+func leaderboardRows(
+	st *state.State,
+	usersXP []levelsdb.UserXP,
+	curve levelsdb.LevelCurve) []string {
+
+	userList := make([]string, 0, len(usersXP))
+	for i, uxp := range usersXP {
+		var username string
+		user, err := st.User(uxp.UserID)
+		if err != nil {
+			log.Println(err)
+			username = uxp.UserID.Mention()
+		} else {
+			username = dctools.EscapeMarkdown(user.Username)
+		}
+
+		var row string
+		if curve != nil {
+			row = fmt.Sprintf(
+				"%d. %s (Lvl %s) - %s XP",
+				i+1,
+				username,
+				humanize.Comma(int64(uxp.Level(curve))),
+				humanize.Comma(uxp.XP),
+			)
+		} else {
+			row = fmt.Sprintf(
+				"%d. %s - %s XP", i+1, username, humanize.Comma(uxp.XP),
+			)
+		}
+
+		userList = append(userList, row)
+	}
+
+	return userList
+}
@@ -0,0 +1,347 @@
+package user
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/dustin/go-humanize"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/htmlutil"
+)
+
+const (
+	rankCardWidth  = 900
+	rankCardHeight = 250
+	// rankNeighbourDistance is the number of users listed above and below a
+	// user's rank.
+	rankNeighbourDistance = 2
+)
+
+type rankCardData struct {
+	Username     string  `json:"username"`
+	AvatarURL    string  `json:"avatarUrl"`
+	Level        string  `json:"level"`
+	Rank         string  `json:"rank"`
+	Total        string  `json:"total"`
+	XP           string  `json:"xp"`
+	LevelXP      string  `json:"levelXp"`
+	Progress     float64 `json:"progress"`
+	Scope        string  `json:"scope"`
+	VoiceTime    string  `json:"voiceTime"`
+	AccentColour string  `json:"accentColour"`
+}
+
+var levelsRankCommand = &router.SubCommand{
+	Name:        "rank",
+	Description: "Displays the level and XP of a user",
+	Handler: &router.CommandHandler{
+		Executor: levelsRankExec,
+		Defer:    true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The user to display the level of",
+		},
+		&discord.IntegerOption{
+			OptionName:  "scope",
+			Description: "Where to fetch the user's level from",
+			Choices: []discord.IntegerChoice{
+				{Name: "Server", Value: serverScope},
+				{Name: "Global", Value: globalScope},
+			},
+		},
+	},
+}
+
+func levelsRankExec(ctx router.CommandCtx) {
+	userSnowflake, _ := ctx.Options.Find("user").SnowflakeValue()
+	scope, _ := ctx.Options.Find("scope").IntValue()
+
+	userID := discord.UserID(userSnowflake)
+	if !userID.IsValid() {
+		userID = ctx.Interaction.SenderID()
+	}
+
+	user, err := ctx.State.User(userID)
+	if dctools.ErrUnknownUser(err) {
+		ctx.RespondWarning("User does not exist.")
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching user data.")
+		return
+	}
+
+	var stats *levelsdb.UserXP
+	switch scope {
+	case serverScope:
+		stats, err = db.Levels.GetUserStats(ctx.Interaction.GuildID, userID)
+	case globalScope:
+		stats, err = db.Levels.GetUserGlobalStats(userID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.RespondWarningf("%s has not earned any XP yet.", user.Mention())
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching user XP.")
+		return
+	}
+
+	var guildID discord.GuildID
+	if scope == serverScope {
+		guildID = ctx.Interaction.GuildID
+	}
+
+	rank, neighbours, err := fetchUserRank(guildID, userID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching user rank.")
+		return
+	}
+
+	curve := levelCurve(guildID)
+	neighbourList := neighbourRows(ctx.State, neighbours, userID, curve)
+
+	card, err := rankCardImage(*user, *stats, *rank, curve, scope)
+	if err != nil {
+		log.Println(err)
+		embed := rankEmbed(*user, *stats, *rank, curve, scope)
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  "Nearby",
+			Value: strings.Join(neighbourList, "\n"),
+		})
+		ctx.RespondEmbed(embed)
+		return
+	}
+
+	fileName := fmt.Sprintf(
+		"%s-rank-%s.png", user.Username, time.Now().Format(time.RFC3339),
+	)
+
+	ctx.RespondMessage(api.InteractionResponseData{
+		Embeds: &[]discord.Embed{
+			{
+				Description: strings.Join(neighbourList, "\n"),
+				Color:       dctools.EmbedBackColour,
+				Footer: &discord.EmbedFooter{
+					Text: rankPosition(*rank),
+				},
+			},
+		},
+		Files: []sendpart.File{
+			{Name: fileName, Reader: bytes.NewBuffer(card)},
+		},
+	})
+}
+
+// fetchUserRank returns the rank position of a user in a guild, or globally
+// if the guild ID is invalid, along with the users ranked around them.
+func fetchUserRank(
+	guildID discord.GuildID,
+	userID discord.UserID) (*levelsdb.UserRank, []levelsdb.RankedUserXP, error) {
+
+	if !guildID.IsValid() {
+		rank, err := db.Levels.GetUserGlobalRank(userID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		neighbours, err := db.Levels.GetUserGlobalNeighbours(
+			userID, rankNeighbourDistance,
+		)
+		return rank, neighbours, err
+	}
+
+	rank, err := db.Levels.GetUserRank(guildID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	neighbours, err := db.Levels.GetUserNeighbours(
+		guildID, userID, rankNeighbourDistance,
+	)
+	return rank, neighbours, err
+}
+
+// neighbourRows returns the leaderboard rows for the users ranked around a
+// user, with their levels on the provided level curve and the user's own row
+// in bold.
+func neighbourRows(
+	st *state.State,
+	neighbours []levelsdb.RankedUserXP,
+	userID discord.UserID,
+	curve levelsdb.LevelCurve) []string {
+
+	rows := make([]string, 0, len(neighbours))
+	for _, n := range neighbours {
+		var username string
+		user, err := st.User(n.UserID)
+		if err != nil {
+			log.Println(err)
+			username = n.UserID.Mention()
+		} else {
+			username = dctools.EscapeMarkdown(user.Username)
+		}
+
+		row := fmt.Sprintf(
+			"%s. %s (Lvl %s) - %s XP",
+			humanize.Comma(n.Rank),
+			username,
+			humanize.Comma(int64(n.Level(curve))),
+			humanize.Comma(n.XP),
+		)
+		if n.UserID == userID {
+			row = dctools.Bold(row)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// rankPosition formats a user's rank position out of the total users ranked,
+// e.g. #342 of 5,120.
+func rankPosition(rank levelsdb.UserRank) string {
+	return fmt.Sprintf(
+		"#%s of %s", humanize.Comma(rank.Rank), humanize.Comma(rank.Total),
+	)
+}
+
+// rankCardImage renders a rank card image displaying a user's level, XP
+// progress and rank position.
+func rankCardImage(
+	user discord.User,
+	stats levelsdb.UserXP,
+	rank levelsdb.UserRank,
+	curve levelsdb.LevelCurve,
+	scope int64) ([]byte, error) {
+
+	avatarURL := user.AvatarURLWithType(discord.PNGImage)
+	colour, err := dctools.EmbedImageColour(avatarURL)
+	if err != nil || dctools.ColourInvalid(colour) {
+		colour = dctools.BlurpleColour
+	}
+
+	progress := stats.Progress(curve)
+	levelXP := progress.NextLevelXP - (stats.XP - progress.XP)
+
+	var percent float64
+	if levelXP > 0 {
+		percent = float64(progress.XP) / float64(levelXP) * 100
+	}
+
+	scopeName := "Server"
+	if scope == globalScope {
+		scopeName = "Global"
+	}
+
+	data := rankCardData{
+		Username:     user.DisplayOrUsername(),
+		AvatarURL:    dctools.ResizeImage(avatarURL, 256),
+		Level:        humanize.Comma(int64(progress.CurrentLevel)),
+		Rank:         humanize.Comma(rank.Rank),
+		Total:        humanize.Comma(rank.Total),
+		XP:           humanize.Comma(progress.XP),
+		LevelXP:      humanize.Comma(levelXP),
+		Progress:     math.Min(percent, 100),
+		Scope:        scopeName,
+		VoiceTime:    formatVoiceTime(stats.VoiceSeconds),
+		AccentColour: colour.String(),
+	}
+
+	jsonContext, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return htmlutil.TemplateToPNG(
+		"levels-rank", jsonContext, rankCardWidth, rankCardHeight,
+	)
+}
+
+// rankEmbed returns an embed displaying a user's level, XP and rank position,
+// used when a rank card image cannot be rendered.
+func rankEmbed(
+	user discord.User,
+	stats levelsdb.UserXP,
+	rank levelsdb.UserRank,
+	curve levelsdb.LevelCurve,
+	scope int64) discord.Embed {
+
+	title := user.DisplayOrUsername()
+	if scope == globalScope {
+		title += " (Global)"
+	}
+
+	progress := stats.Progress(curve)
+	remaining := progress.NextLevelXP - stats.XP
+
+	return discord.Embed{
+		Author: &discord.EmbedAuthor{
+			Name: title,
+			Icon: user.AvatarURL(),
+		},
+		Fields: []discord.EmbedField{
+			{
+				Name:   "Rank",
+				Value:  rankPosition(rank),
+				Inline: true,
+			},
+			{
+				Name:   "Level",
+				Value:  humanize.Comma(int64(progress.CurrentLevel)),
+				Inline: true,
+			},
+			{
+				Name:   "XP",
+				Value:  humanize.Comma(stats.XP),
+				Inline: true,
+			},
+			{
+				Name:   "Voice Time",
+				Value:  formatVoiceTime(stats.VoiceSeconds),
+				Inline: true,
+			},
+		},
+		Color: dctools.EmbedBackColour,
+		Footer: &discord.EmbedFooter{
+			Text: fmt.Sprintf(
+				"%s XP to level %s",
+				humanize.Comma(remaining),
+				humanize.Comma(int64(progress.CurrentLevel+1)),
+			),
+		},
+	}
+}
+
+// formatVoiceTime formats a number of seconds spent in voice channels in
+// hours and minutes, e.g. 3h 25m.
+func formatVoiceTime(seconds int64) string {
+	voiceTime := time.Duration(seconds) * time.Second
+
+	hours := int64(voiceTime / time.Hour)
+	minutes := int64(voiceTime % time.Hour / time.Minute)
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%sh %dm", humanize.Comma(hours), minutes)
+}
@@ -0,0 +1,146 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var levelsRewardsAddCommand = &router.SubCommand{
+	Name:        "add",
+	Description: "Add a role to be given to members when they reach a level",
+	Handler: &router.CommandHandler{
+		Executor: levelsRewardsAddExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "level",
+			Description: "The level members must reach to be given the role",
+			Required:    true,
+			Min:         option.NewInt(1),
+			Max:         option.NewInt(1000),
+		},
+		&discord.RoleOption{
+			OptionName:  "role",
+			Description: "The role to give members",
+			Required:    true,
+		},
+	},
+}
+
+func levelsRewardsAddExec(ctx router.CommandCtx) {
+	if !checkRewardsPermission(ctx) {
+		return
+	}
+
+	level, _ := ctx.Options.Find("level").IntValue()
+	snowflake, _ := ctx.Options.Find("role").SnowflakeValue()
+	roleID := discord.RoleID(snowflake)
+	if !roleID.IsValid() {
+		ctx.RespondWarning("Malformed role ID provided.")
+		return
+	}
+
+	if dctools.IsEveryoneRole(ctx.Interaction.GuildID, roleID) {
+		ctx.RespondWarning("I cannot assign `@everyone` roles!")
+		return
+	}
+
+	role, err := ctx.State.Role(ctx.Interaction.GuildID, roleID)
+	if err != nil {
+		ctx.RespondError("Error occurred fetching role.")
+		return
+	}
+
+	if role.Managed {
+		ctx.RespondWarning("I cannot assign managed bot roles to users!")
+		return
+	}
+
+	botUser, err := ctx.State.Me()
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking role permissions.")
+		return
+	}
+
+	botCanModify, err := dctools.MemberCanModifyRole(
+		ctx.State,
+		ctx.Interaction.GuildID,
+		ctx.Interaction.ChannelID,
+		botUser.ID,
+		roleID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking role permissions.")
+		return
+	}
+	if !botCanModify {
+		ctx.RespondWarning(
+			"I cannot assign roles that are positioned above me in " +
+				"the role order!",
+		)
+		return
+	}
+
+	senderCanModify, err := dctools.MemberCanModifyRole(
+		ctx.State,
+		ctx.Interaction.GuildID,
+		ctx.Interaction.ChannelID,
+		ctx.Interaction.SenderID(),
+		roleID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking role permissions.")
+		return
+	}
+	if !senderCanModify {
+		ctx.RespondWarning(
+			"You cannot add roles that are positioned above you in " +
+				"the role order!",
+		)
+		return
+	}
+
+	rewards, err := db.Levels.GetRoleRewards(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking role rewards.")
+		return
+	}
+
+	exists := false
+	for _, r := range rewards {
+		if r.RoleID == roleID {
+			exists = true
+			break
+		}
+	}
+	if !exists && len(rewards) >= roleRewardLimit {
+		ctx.RespondWarningf(
+			"You cannot have more than %d role rewards in a server.",
+			roleRewardLimit,
+		)
+		return
+	}
+
+	_, err = db.Levels.AddRoleReward(
+		ctx.Interaction.GuildID, int32(level), roleID,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while adding role reward.")
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"%s will now be given to members when they reach level %d.",
+		roleID.Mention(),
+		level,
+	)
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var levelsRewardsListCommand = &router.SubCommand{
+	Name:        "list",
+	Description: "Lists all roles given to members for levelling up",
+	Handler: &router.CommandHandler{
+		Executor: levelsRewardsListExec,
+	},
+}
+
+func levelsRewardsListExec(ctx router.CommandCtx) {
+	if !ctx.Interaction.GuildID.IsValid() {
+		ctx.RespondWarning("Role rewards can only be listed in a server.")
+		return
+	}
+
+	rewards, err := db.Levels.GetRoleRewards(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching role rewards.")
+		return
+	}
+	if len(rewards) < 1 {
+		ctx.RespondWarning("This server has no role rewards added to it.")
+		return
+	}
+
+	mode := "Stacking"
+	config, err := db.Levels.GetLevelConfig(ctx.Interaction.GuildID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+	}
+	if err == nil && !config.StackRewards {
+		mode = "Replacing"
+	}
+
+	rewardList := make([]string, 0, len(rewards))
+	for _, r := range rewards {
+		rewardList = append(
+			rewardList, fmt.Sprintf("- Level %d: %s", r.Level, r.RoleID.Mention()),
+		)
+	}
+
+	name := "Server"
+	guild, err := ctx.State.Guild(ctx.Interaction.GuildID)
+	if err == nil {
+		name = guild.Name
+	}
+
+	descriptionPages := util.PagedLines(rewardList, 2048, 20)
+	pages := make([]router.MessagePage, len(descriptionPages))
+	footer := util.PluraliseWithCount("Role Reward", int64(len(rewardList)))
+
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Author: &discord.EmbedAuthor{
+						Name: fmt.Sprintf(
+							"%s Level Role Rewards", name,
+						),
+					},
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(
+							pageID,
+							footer,
+							mode,
+						),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
@@ -0,0 +1,61 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+const (
+	stackRewardsMode = iota
+	replaceRewardsMode
+)
+
+var levelsRewardsModeCommand = &router.SubCommand{
+	Name:        "mode",
+	Description: "Sets whether members keep role rewards for lower levels",
+	Handler: &router.CommandHandler{
+		Executor: levelsRewardsModeExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "mode",
+			Description: "How role rewards are given to members",
+			Required:    true,
+			Choices: []discord.IntegerChoice{
+				{Name: "Stack", Value: stackRewardsMode},
+				{Name: "Replace", Value: replaceRewardsMode},
+			},
+		},
+	},
+}
+
+func levelsRewardsModeExec(ctx router.CommandCtx) {
+	if !checkRewardsPermission(ctx) {
+		return
+	}
+
+	mode, _ := ctx.Options.Find("mode").IntValue()
+	stack := mode == stackRewardsMode
+
+	_, err := db.Levels.SetStackRewards(ctx.Interaction.GuildID, stack)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting role reward mode.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	if stack {
+		ctx.RespondSuccess(
+			"Members will now keep role rewards for lower levels.",
+		)
+	} else {
+		ctx.RespondSuccess(
+			"Role rewards for lower levels will now be replaced by the " +
+				"role rewards for the highest level members have reached.",
+		)
+	}
+}
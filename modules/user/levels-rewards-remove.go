@@ -0,0 +1,52 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsRewardsRemoveCommand = &router.SubCommand{
+	Name:        "remove",
+	Description: "Stop a role from being given to members for levelling up",
+	Handler: &router.CommandHandler{
+		Executor: levelsRewardsRemoveExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.RoleOption{
+			OptionName:  "role",
+			Description: "The role to stop giving members",
+			Required:    true,
+		},
+	},
+}
+
+func levelsRewardsRemoveExec(ctx router.CommandCtx) {
+	if !checkRewardsPermission(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("role").SnowflakeValue()
+	roleID := discord.RoleID(snowflake)
+	if !roleID.IsValid() {
+		ctx.RespondWarning("Malformed role ID provided.")
+		return
+	}
+
+	removed, err := db.Levels.RemoveRoleReward(ctx.Interaction.GuildID, roleID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while removing role reward.")
+		return
+	}
+	if !removed {
+		ctx.RespondWarning("This role is not a level role reward.")
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"%s will no longer be given to members for levelling up.",
+		roleID.Mention(),
+	)
+}
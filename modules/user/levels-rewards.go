@@ -0,0 +1,21 @@
+package user
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+const roleRewardLimit = 50
+
+var levelsRewardsCommand = &router.SubCommandGroup{
+	Name:        "rewards",
+	Description: "Commands pertaining to roles given to members for levelling up",
+}
+
+// checkRewardsPermission responds with a warning and returns false if the
+// user cannot manage role rewards.
+func checkRewardsPermission(ctx router.CommandCtx) bool {
+	return checkLevelsPermission(
+		ctx, discord.PermissionManageRoles, "Manage Roles",
+	)
+}
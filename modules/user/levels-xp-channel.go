@@ -0,0 +1,52 @@
+package user
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var levelsXPChannelCommand = &router.SubCommand{
+	Name:        "channel",
+	Description: "Sets the XP multiplier for a channel, 0 disabling XP",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPChannelExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.ChannelOption{
+			OptionName:   "channel",
+			Description:  "The channel to set the XP multiplier for",
+			Required:     true,
+			ChannelTypes: dctools.TextChannelTypes(),
+		},
+		&discord.NumberOption{
+			OptionName:  "multiplier",
+			Description: "The multiplier to apply to XP earned in the channel",
+			Required:    true,
+			Min:         option.NewFloat(0),
+			Max:         option.NewFloat(maxMultiplier),
+		},
+	},
+}
+
+func levelsXPChannelExec(ctx router.CommandCtx) {
+	if !checkXPPermission(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("channel").SnowflakeValue()
+	channelID := discord.ChannelID(snowflake)
+	if !channelID.IsValid() {
+		ctx.RespondWarning("Malformed Discord channel provided.")
+		return
+	}
+
+	setXPRule(
+		ctx,
+		discord.Snowflake(channelID),
+		levelsdb.ChannelXPRule,
+		channelID.Mention(),
+	)
+}
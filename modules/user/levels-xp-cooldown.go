@@ -0,0 +1,56 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var levelsXPCooldownCommand = &router.SubCommand{
+	Name: "cooldown",
+	Description: "Sets how long after sending a message members earn " +
+		"reduced XP",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPCooldownExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "seconds",
+			Description: "The length of the cooldown in seconds",
+			Required:    true,
+			Min:         option.NewInt(0),
+			Max:         option.NewInt(3600),
+		},
+	},
+}
+
+func levelsXPCooldownExec(ctx router.CommandCtx) {
+	if !checkXPPermission(ctx) {
+		return
+	}
+
+	seconds, _ := ctx.Options.Find("seconds").IntValue()
+
+	_, err := db.Levels.SetXPCooldown(ctx.Interaction.GuildID, int32(seconds))
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting XP cooldown.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	if seconds == 0 {
+		ctx.RespondSuccess("XP cooldown disabled.")
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"Members will now earn reduced XP for messages sent within %s "+
+			"of their last message.",
+		util.PluraliseWithCount("second", seconds),
+	)
+}
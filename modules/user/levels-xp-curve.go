@@ -0,0 +1,174 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/dustin/go-humanize"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const maxCurveTableLevels = 200
+
+var levelsXPCurveCommand = &router.SubCommand{
+	Name:        "curve",
+	Description: "Sets how much XP members need to reach each level",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPCurveExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "type",
+			Description: "The type of level curve to use",
+			Required:    true,
+			Choices: []discord.IntegerChoice{
+				{
+					Name:  levelsdb.LogLevelCurve.String(),
+					Value: int(levelsdb.LogLevelCurve),
+				},
+				{
+					Name:  levelsdb.LinearLevelCurve.String(),
+					Value: int(levelsdb.LinearLevelCurve),
+				},
+				{
+					Name:  levelsdb.QuadraticLevelCurve.String() + " (MEE6)",
+					Value: int(levelsdb.QuadraticLevelCurve),
+				},
+				{
+					Name:  levelsdb.TableLevelCurve.String(),
+					Value: int(levelsdb.TableLevelCurve),
+				},
+			},
+		},
+		&discord.IntegerOption{
+			OptionName:  "xp-per-level",
+			Description: "The XP required for each level on a linear curve",
+			Min:         option.NewInt(1),
+			Max:         option.NewInt(1_000_000),
+		},
+		&discord.StringOption{
+			OptionName: "levels",
+			Description: "The total XP required for each level on a custom " +
+				"curve, e.g. 100, 250, 500",
+			MaxLength: option.NewInt(2048),
+		},
+	},
+}
+
+func levelsXPCurveExec(ctx router.CommandCtx) {
+	if !checkXPPermission(ctx) {
+		return
+	}
+
+	curveOption, _ := ctx.Options.Find("type").IntValue()
+	xpPerLevel, _ := ctx.Options.Find("xp-per-level").IntValue()
+	levels := ctx.Options.Find("levels").String()
+
+	curveType := levelsdb.LevelCurveType(curveOption)
+
+	var table []int64
+	if curveType == levelsdb.TableLevelCurve {
+		var cerr router.CmdResponse
+		table, cerr = parseCurveTable(levels)
+		if cerr != nil {
+			ctx.RespondCmdMessage(cerr)
+			return
+		}
+	}
+
+	_, err := db.Levels.SetLevelCurve(
+		ctx.Interaction.GuildID, curveType, xpPerLevel, table,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting level curve.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	config := levelsdb.LevelConfig{
+		CurveType:  curveType,
+		CurveXP:    xpPerLevel,
+		CurveTable: table,
+	}
+
+	ctx.RespondSuccessf("Level curve set to %s.", formatLevelCurve(config))
+}
+
+// parseCurveTable parses a comma separated list of the total XP required to
+// reach each level, starting at level 1.
+func parseCurveTable(levels string) ([]int64, router.CmdResponse) {
+	if strings.TrimSpace(levels) == "" {
+		return nil, router.Warning(
+			"Please provide the total XP required for each level to use a " +
+				"custom curve.",
+		)
+	}
+
+	fields := strings.Split(levels, ",")
+	if len(fields) > maxCurveTableLevels {
+		return nil, router.Warningf(
+			"Custom curves cannot have more than %d levels.",
+			maxCurveTableLevels,
+		)
+	}
+
+	table := make([]int64, 0, len(fields))
+	for i, f := range fields {
+		f = strings.ReplaceAll(strings.TrimSpace(f), "_", "")
+
+		xp, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, router.Warningf("%q is not a valid amount of XP.", f)
+		}
+		if xp < 1 || xp > maxAdminXP {
+			return nil, router.Warningf(
+				"Level %d must require between 1 and %s XP.",
+				i+1,
+				humanize.Comma(maxAdminXP),
+			)
+		}
+		if i > 0 && xp <= table[i-1] {
+			return nil, router.Warningf(
+				"Level %d must require more XP than level %d.", i+1, i,
+			)
+		}
+
+		table = append(table, xp)
+	}
+
+	return table, nil
+}
+
+// formatLevelCurve describes the level curve used by a level config, e.g.
+// Linear (1,000 XP per level).
+func formatLevelCurve(config levelsdb.LevelConfig) string {
+	name := config.CurveType.String()
+
+	curve := config.Curve()
+	switch config.CurveType {
+	case levelsdb.LinearLevelCurve:
+		return fmt.Sprintf(
+			"%s (%s XP per level)", name, humanize.Comma(curve.RequiredXP(1)),
+		)
+	case levelsdb.TableLevelCurve:
+		return fmt.Sprintf(
+			"%s (%s)",
+			name,
+			util.PluraliseWithCount("level", int64(len(config.CurveTable))),
+		)
+	default:
+		return fmt.Sprintf(
+			"%s (%s XP for level 10)",
+			name,
+			humanize.Comma(curve.RequiredXP(10)),
+		)
+	}
+}
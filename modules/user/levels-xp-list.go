@@ -0,0 +1,102 @@
+package user
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+var levelsXPListCommand = &router.SubCommand{
+	Name:        "list",
+	Description: "Lists the XP settings for the server",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPListExec,
+	},
+}
+
+func levelsXPListExec(ctx router.CommandCtx) {
+	if !ctx.Interaction.GuildID.IsValid() {
+		ctx.RespondWarning("XP settings can only be listed in a server.")
+		return
+	}
+
+	levelConfig, err := levelConfigs.Config(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching XP settings.")
+		return
+	}
+
+	rules, err := db.Levels.GetXPRules(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching XP rules.")
+		return
+	}
+
+	config := levelConfig.config
+	ruleList := []string{
+		fmt.Sprintf(
+			"Cooldown: %s",
+			util.PluraliseWithCount("second", int64(config.CooldownSeconds)),
+		),
+		"Weekend Multiplier: " + formatMultiplier(config.WeekendMultiplier),
+		"Level Curve: " + formatLevelCurve(config),
+		"",
+	}
+
+	for _, r := range rules {
+		var mention string
+		switch r.TargetType {
+		case levelsdb.ChannelXPRule:
+			mention = discord.ChannelID(r.TargetID).Mention()
+		case levelsdb.RoleXPRule:
+			mention = discord.RoleID(r.TargetID).Mention()
+		}
+
+		multiplier := formatMultiplier(r.Multiplier)
+		if r.Multiplier == 0 {
+			multiplier = "No XP"
+		}
+
+		ruleList = append(ruleList, fmt.Sprintf("- %s: %s", mention, multiplier))
+	}
+
+	name := "Server"
+	guild, err := ctx.State.Guild(ctx.Interaction.GuildID)
+	if err == nil {
+		name = guild.Name
+	}
+
+	descriptionPages := util.PagedLines(ruleList, 2048, 25)
+	pages := make([]router.MessagePage, len(descriptionPages))
+	footer := util.PluraliseWithCount("XP Rule", int64(len(rules)))
+
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Author: &discord.EmbedAuthor{
+						Name: fmt.Sprintf("%s XP Settings", name),
+					},
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(
+							pageID,
+							footer,
+						),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+)
+
+var levelsXPRemoveCommand = &router.SubCommand{
+	Name:        "remove",
+	Description: "Removes the XP multiplier for a channel or role",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPRemoveExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.ChannelOption{
+			OptionName:   "channel",
+			Description:  "The channel to remove the XP multiplier for",
+			ChannelTypes: dctools.TextChannelTypes(),
+		},
+		&discord.RoleOption{
+			OptionName:  "role",
+			Description: "The role to remove the XP multiplier for",
+		},
+	},
+}
+
+func levelsXPRemoveExec(ctx router.CommandCtx) {
+	if !checkXPPermission(ctx) {
+		return
+	}
+
+	channelID, _ := ctx.Options.Find("channel").SnowflakeValue()
+	roleID, _ := ctx.Options.Find("role").SnowflakeValue()
+
+	var (
+		targetID discord.Snowflake
+		mention  string
+	)
+	switch {
+	case channelID.IsValid() && roleID.IsValid():
+		ctx.RespondWarning("Provide either a channel or a role, not both.")
+		return
+	case channelID.IsValid():
+		targetID = channelID
+		mention = discord.ChannelID(channelID).Mention()
+	case roleID.IsValid():
+		targetID = roleID
+		mention = discord.RoleID(roleID).Mention()
+	default:
+		ctx.RespondWarning("Provide a channel or role to remove.")
+		return
+	}
+
+	removed, err := db.Levels.RemoveXPRule(ctx.Interaction.GuildID, targetID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while removing XP rule.")
+		return
+	}
+	if !removed {
+		ctx.RespondWarningf("%s has no XP multiplier set.", mention)
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	ctx.RespondSuccessf("XP multiplier for %s removed.", mention)
+}
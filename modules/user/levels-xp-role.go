@@ -0,0 +1,51 @@
+package user
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsXPRoleCommand = &router.SubCommand{
+	Name: "role",
+	Description: "Sets the XP multiplier for members with a role, " +
+		"0 disabling XP",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPRoleExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.RoleOption{
+			OptionName:  "role",
+			Description: "The role to set the XP multiplier for",
+			Required:    true,
+		},
+		&discord.NumberOption{
+			OptionName:  "multiplier",
+			Description: "The multiplier to apply to XP earned by the role",
+			Required:    true,
+			Min:         option.NewFloat(0),
+			Max:         option.NewFloat(maxMultiplier),
+		},
+	},
+}
+
+func levelsXPRoleExec(ctx router.CommandCtx) {
+	if !checkXPPermission(ctx) {
+		return
+	}
+
+	snowflake, _ := ctx.Options.Find("role").SnowflakeValue()
+	roleID := discord.RoleID(snowflake)
+	if !roleID.IsValid() {
+		ctx.RespondWarning("Malformed role ID provided.")
+		return
+	}
+
+	setXPRule(
+		ctx,
+		discord.Snowflake(roleID),
+		levelsdb.RoleXPRule,
+		"messages by members with "+roleID.Mention(),
+	)
+}
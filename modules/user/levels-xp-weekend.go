@@ -0,0 +1,56 @@
+package user
+
+import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+var levelsXPWeekendCommand = &router.SubCommand{
+	Name:        "weekend",
+	Description: "Sets the XP multiplier for messages sent on weekends",
+	Handler: &router.CommandHandler{
+		Executor: levelsXPWeekendExec,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.NumberOption{
+			OptionName: "multiplier",
+			Description: "The multiplier to apply on Saturdays and Sundays, " +
+				"1 disabling the bonus",
+			Required: true,
+			Min:      option.NewFloat(1),
+			Max:      option.NewFloat(maxMultiplier),
+		},
+	},
+}
+
+func levelsXPWeekendExec(ctx router.CommandCtx) {
+	if !checkXPPermission(ctx) {
+		return
+	}
+
+	multiplier, _ := ctx.Options.Find("multiplier").FloatValue()
+
+	_, err := db.Levels.SetWeekendMultiplier(
+		ctx.Interaction.GuildID, multiplier,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting weekend multiplier.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	if multiplier == 1 {
+		ctx.RespondSuccess("Weekend XP bonus disabled.")
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"XP earned on weekends (UTC) will now be multiplied by %s.",
+		formatMultiplier(multiplier),
+	)
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+const (
+	xpRuleLimit   = 50
+	maxMultiplier = 10
+)
+
+var levelsXPCommand = &router.SubCommandGroup{
+	Name:        "xp",
+	Description: "Commands pertaining to how members earn XP in the server",
+}
+
+// checkXPPermission responds with a warning and returns false if the user
+// cannot manage XP rules.
+func checkXPPermission(ctx router.CommandCtx) bool {
+	return checkLevelsPermission(
+		ctx, discord.PermissionManageGuild, "Manage Server",
+	)
+}
+
+// setXPRule sets the XP multiplier for a channel or role and responds with
+// the result.
+func setXPRule(
+	ctx router.CommandCtx,
+	targetID discord.Snowflake,
+	targetType levelsdb.XPRuleType,
+	mention string) {
+
+	multiplier, _ := ctx.Options.Find("multiplier").FloatValue()
+
+	rules, err := db.Levels.GetXPRules(ctx.Interaction.GuildID)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking XP rules.")
+		return
+	}
+
+	exists := false
+	for _, r := range rules {
+		if r.TargetID == targetID {
+			exists = true
+			break
+		}
+	}
+	if !exists && len(rules) >= xpRuleLimit {
+		ctx.RespondWarningf(
+			"You cannot have more than %d XP rules in a server.",
+			xpRuleLimit,
+		)
+		return
+	}
+
+	_, err = db.Levels.SetXPRule(
+		ctx.Interaction.GuildID, targetID, targetType, multiplier,
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while setting XP rule.")
+		return
+	}
+
+	levelConfigs.Invalidate(ctx.Interaction.GuildID)
+
+	if multiplier == 0 {
+		ctx.RespondSuccessf("No XP will now be earned in %s.", mention)
+		return
+	}
+
+	ctx.RespondSuccessf(
+		"XP earned in %s will now be multiplied by %s.",
+		mention,
+		formatMultiplier(multiplier),
+	)
+}
+
+// formatMultiplier formats an XP multiplier, e.g. 1.5 becomes x1.5.
+func formatMultiplier(multiplier float64) string {
+	return "x" + strconv.FormatFloat(multiplier, 'f', -1, 32)
+}
@@ -1,7 +1,11 @@
 package user
 
 import (
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
 )
 
 const (
@@ -13,3 +17,35 @@ var levelsCommand = &router.Command{
 	Name:        "levels",
 	Description: "Commands pertaining to user levels",
 }
+
+// checkLevelsPermission responds with a warning and returns false if the user
+// is not in a server or does not have the provided permission.
+func checkLevelsPermission(
+	ctx router.CommandCtx,
+	permission discord.Permissions,
+	permissionName string) bool {
+
+	if !ctx.Interaction.GuildID.IsValid() {
+		ctx.RespondWarning("Level settings can only be managed in a server.")
+		return false
+	}
+
+	permissions, err := ctx.State.Permissions(
+		ctx.Interaction.ChannelID, ctx.Interaction.SenderID(),
+	)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while checking your permissions.")
+		return false
+	}
+	if !dctools.HasAnyPermOrAdmin(permissions, permission) {
+		ctx.RespondWarningf(
+			"You need the %s permission to manage this server's level "+
+				"settings.",
+			permissionName,
+		)
+		return false
+	}
+
+	return true
+}
@@ -6,14 +6,18 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"strings"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/dustin/go-humanize"
 	"github.com/twoscott/haseul-bot-2/router"
 	"github.com/twoscott/haseul-bot-2/utils/dctools"
 	"github.com/twoscott/haseul-bot-2/utils/util"
 )
 
+const repReasonMaxLength = 256
+
 var repGiveCommand = &router.SubCommand{
 	Name:        "give",
 	Description: "Gives a rep to a user",
@@ -26,6 +30,11 @@ var repGiveCommand = &router.SubCommand{
 			Description: "The user to give a rep to",
 			Required:    true,
 		},
+		&discord.StringOption{
+			OptionName:  "reason",
+			Description: "Why you are giving the user a rep",
+			MaxLength:   option.NewInt(repReasonMaxLength),
+		},
 	},
 }
 
@@ -51,7 +60,8 @@ func repGiveExec(ctx router.CommandCtx) {
 		return
 	}
 
-	cutoff := getRepCutoff()
+	location := repLocation(senderID)
+	cutoff := getRepCutoff(location)
 	if !lastRep.Before(cutoff) {
 		ctx.RespondWarning(
 			"You cannot rep the same user more than once in the same day!",
@@ -59,7 +69,7 @@ func repGiveExec(ctx router.CommandCtx) {
 		return
 	}
 
-	remaining, err := db.Reps.GetUserRepsRemaining(ctx.Interaction.SenderID())
+	remaining, err := db.Reps.GetUserRepsRemaining(senderID, cutoff)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while checking remaining reps.")
@@ -67,7 +77,7 @@ func repGiveExec(ctx router.CommandCtx) {
 	}
 
 	if remaining == 0 {
-		nextRepTime := getNextRepResetFromNow()
+		nextRepTime := getNextRepResetFromNow(location)
 		ctx.RespondWarning(
 			fmt.Sprintf(
 				"You have no reps remaining! Your reps will be replenished %s.",
@@ -77,14 +87,31 @@ func repGiveExec(ctx router.CommandCtx) {
 		return
 	}
 
-	rep, err := db.Reps.RepUser(senderID, targetID)
+	reason := strings.TrimSpace(ctx.Options.Find("reason").String())
+
+	// use a streak freeze before the rep is given if the streak has expired,
+	// so the rep continues the streak instead of restarting it.
+	status, err := db.Reps.GetStreakStatus(senderID, targetID)
+	if err == nil {
+		applyStreakFreeze(ctx.Router, *status)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+	}
+
+	rep, err := db.Reps.RepUser(
+		ctx.Interaction.GuildID,
+		senderID,
+		targetID,
+		reason,
+		getRepStreakWindow(location),
+	)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while attempting to rep user.")
 		return
 	}
 
-	streak, err := db.Reps.GetUserStreak(senderID, targetID)
+	streak, err := db.Reps.GetStreakStatus(senderID, targetID)
 	if err != nil {
 		log.Println(err)
 	}
@@ -102,9 +129,24 @@ func repGiveExec(ctx router.CommandCtx) {
 		Color: dctools.EmbedBackColour,
 	}
 
+	if ctx.Interaction.GuildID.IsValid() {
+		guildRep, err := db.Reps.GetGuildUserRep(
+			ctx.Interaction.GuildID, targetID,
+		)
+		if err != nil {
+			log.Println(err)
+		} else {
+			embed.Fields = append(embed.Fields, discord.EmbedField{
+				Name:   "Server Rep",
+				Value:  humanize.Comma(int64(guildRep)),
+				Inline: true,
+			})
+		}
+	}
+
 	days := streak.Days()
 	if days > 0 {
-		emojis := getStreakEmojiString(streak)
+		emojis := getStreakEmojiString(*streak)
 
 		hue := rand.Float64() * 360
 		colour := dctools.HSVToColour(hue, 0.5, 0.9)
@@ -122,7 +164,17 @@ func repGiveExec(ctx router.CommandCtx) {
 		)
 	}
 
+	if reason != "" {
+		embed.Description = dctools.EscapeMarkdown(reason)
+	}
+
 	message := fmt.Sprintf("You gave a rep to %s!", targetID.Mention())
+	if awardStreakFreezes(streak.RepStreak) {
+		message += fmt.Sprintf(
+			"\n🧊 You both earned a streak freeze for reaching a %s streak!",
+			util.PluraliseWithCount("day", int64(days)),
+		)
+	}
 
 	ctx.RespondSimple(message, embed)
 }
@@ -0,0 +1,108 @@
+package user
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const repHistoryLimit = 100
+
+var repHistoryCommand = &router.SubCommand{
+	Name:        "history",
+	Description: "Displays who has given a user reps and why",
+	Handler: &router.CommandHandler{
+		Executor: repHistoryExec,
+		Defer:    true,
+	},
+	Options: []discord.CommandOptionValue{
+		&discord.UserOption{
+			OptionName:  "user",
+			Description: "The user to display the rep history of",
+		},
+	},
+}
+
+func repHistoryExec(ctx router.CommandCtx) {
+	snowflake, _ := ctx.Options.Find("user").SnowflakeValue()
+	userID := discord.UserID(snowflake)
+	if !userID.IsValid() {
+		userID = ctx.Interaction.SenderID()
+	}
+
+	user, err := ctx.State.User(userID)
+	if dctools.ErrUnknownUser(err) {
+		ctx.RespondWarning("User does not exist.")
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching user data.")
+		return
+	}
+
+	reps, err := db.Reps.GetReceivedReps(userID, repHistoryLimit)
+	if err != nil {
+		log.Println(err)
+		ctx.RespondError("Error occurred while fetching rep history.")
+		return
+	}
+	if len(reps) < 1 {
+		ctx.RespondWarningf("%s has not received any reps yet.", user.Mention())
+		return
+	}
+
+	total, err := db.Reps.GetReceivedRepsCount(userID)
+	if err != nil {
+		log.Println(err)
+		total = int64(len(reps))
+	}
+
+	repList := make([]string, 0, len(reps))
+	for _, r := range reps {
+		// senders are mentioned rather than fetched, as mentions in embeds
+		// don't ping and need no request per sender.
+		row := fmt.Sprintf(
+			"- %s %s",
+			dctools.TimestampStyled(r.Time, dctools.ShortDate),
+			r.SenderID.Mention(),
+		)
+		if r.Reason != "" {
+			row += ": " + dctools.EscapeMarkdown(r.Reason)
+		}
+
+		repList = append(repList, row)
+	}
+
+	descriptionPages := util.PagedLines(repList, 2048, 15)
+	footer := util.PluraliseWithCount("Rep", total) + " Received"
+
+	pages := make([]router.MessagePage, len(descriptionPages))
+	for i, description := range descriptionPages {
+		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
+		pages[i] = router.MessagePage{
+			Embeds: []discord.Embed{
+				{
+					Author: &discord.EmbedAuthor{
+						Name: user.DisplayOrUsername() + " Rep History",
+						Icon: user.AvatarURL(),
+					},
+					Description: description,
+					Color:       dctools.EmbedBackColour,
+					Footer: &discord.EmbedFooter{
+						Text: dctools.SeparateEmbedFooter(
+							pageID,
+							footer,
+						),
+					},
+				},
+			},
+		}
+	}
+
+	ctx.RespondPaging(pages)
+}
@@ -21,6 +21,14 @@ var repLeaderboardCommand = &router.SubCommand{
 		Defer:    true,
 	},
 	Options: []discord.CommandOptionValue{
+		&discord.IntegerOption{
+			OptionName:  "scope",
+			Description: "Where to fetch user reps from",
+			Choices: []discord.IntegerChoice{
+				{Name: "Server", Value: serverScope},
+				{Name: "Global", Value: globalScope},
+			},
+		},
 		&discord.IntegerOption{
 			OptionName:  "users",
 			Description: "The amount of top users to list",
@@ -31,17 +39,40 @@ var repLeaderboardCommand = &router.SubCommand{
 }
 
 func repLeaderboardExec(ctx router.CommandCtx) {
+	scope, _ := ctx.Options.Find("scope").IntValue()
 	limit, _ := ctx.Options.Find("users").IntValue()
 	if limit == 0 {
 		limit = 10
 	}
 
+	guildID := ctx.Interaction.GuildID
+	if !guildID.IsValid() {
+		scope = globalScope
+	}
+
+	listName := "Global"
+	if scope == serverScope {
+		listName = "Server"
+		guild, err := ctx.State.Guild(guildID)
+		if err == nil {
+			listName = guild.Name
+		}
+	}
+
 	var (
-		userReps []repdb.RepUser
-		err      error
+		userReps  []repdb.RepUser
+		totalReps int64
+		err       error
 	)
 
-	userReps, err = db.Reps.GetTopUsers(limit)
+	switch scope {
+	case serverScope:
+		userReps, err = db.Reps.GetTopGuildUsers(guildID, limit)
+		totalReps, _ = db.Reps.GetGuildTotalReps(guildID)
+	case globalScope:
+		userReps, err = db.Reps.GetTopUsers(limit)
+		totalReps, _ = db.Reps.GetTotalReps()
+	}
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while fetching top users.")
@@ -75,9 +106,8 @@ func repLeaderboardExec(ctx router.CommandCtx) {
 		userList = append(userList, row)
 	}
 
-	totalReps, _ := db.Reps.GetTotalReps()
 	descriptionPages := util.PagedLines(userList, 2048, 25)
-	footer := util.PluraliseWithCount("Total Rep", int64(totalReps))
+	footer := util.PluraliseWithCount("Total Rep", totalReps)
 
 	pages := make([]router.MessagePage, len(descriptionPages))
 	for i, description := range descriptionPages {
@@ -85,7 +115,7 @@ func repLeaderboardExec(ctx router.CommandCtx) {
 		pages[i] = router.MessagePage{
 			Embeds: []discord.Embed{
 				{
-					Title:       "Global Rep Leaderboard",
+					Title:       listName + " Rep Leaderboard",
 					Description: description,
 					Color:       dctools.EmbedBackColour,
 					Footer: &discord.EmbedFooter{
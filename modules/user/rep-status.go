@@ -19,7 +19,11 @@ var repStatusCommand = &router.SubCommand{
 }
 
 func repStatusExec(ctx router.CommandCtx) {
-	remaining, err := db.Reps.GetUserRepsRemaining(ctx.Interaction.SenderID())
+	location := repLocation(ctx.Interaction.SenderID())
+
+	remaining, err := db.Reps.GetUserRepsRemaining(
+		ctx.Interaction.SenderID(), getRepCutoff(location),
+	)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while fetching remaining reps.")
@@ -31,7 +35,7 @@ func repStatusExec(ctx router.CommandCtx) {
 		repsString := util.PluraliseWithCount("rep", remaining)
 		message = fmt.Sprintf("You have %s remaining to give.", repsString)
 	} else {
-		resetTime := getNextRepResetFromNow()
+		resetTime := getNextRepResetFromNow(location)
 		timeString := dctools.TimestampStyled(
 			resetTime,
 			dctools.RelativeTime,
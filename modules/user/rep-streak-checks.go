@@ -0,0 +1,231 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/twoscott/haseul-bot-2/database/repdb"
+	"github.com/twoscott/haseul-bot-2/router"
+	"github.com/twoscott/haseul-bot-2/utils/dctools"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+const (
+	repStreakCheckInterval = 15 * time.Minute
+	// streakFreezeMilestoneDays is how many days of a streak it takes for
+	// both users to earn a streak freeze.
+	streakFreezeMilestoneDays = 7
+	maxStreakFreezes          = 3
+)
+
+var repStreakChecksStarted sync.Once
+
+// startRepStreakChecks starts the rep streak check job in the background.
+// Subsequent calls on reconnect do nothing.
+func startRepStreakChecks(rt *router.Router, _ *gateway.ReadyEvent) {
+	repStreakChecksStarted.Do(func() {
+		go repStreakCheckJob(rt)
+	})
+}
+
+func repStreakCheckJob(rt *router.Router) {
+	checkRepStreaks(rt)
+
+	ticker := time.NewTicker(repStreakCheckInterval)
+	for range ticker.C {
+		checkRepStreaks(rt)
+	}
+}
+
+// checkRepStreaks uses streak freezes to save any expired rep streaks, clears
+// any streaks that could not be saved, and warns users about streaks that are
+// about to expire.
+func checkRepStreaks(rt *router.Router) {
+	statuses, err := updateRepStreaks(rt)
+	if err != nil {
+		log.Println(err)
+	}
+
+	for _, s := range statuses {
+		warnStreakExpiry(rt, s)
+	}
+}
+
+// updateRepStreaks uses streak freezes to save any expired rep streaks, then
+// clears any streaks that could not be saved. It returns the statuses of the
+// streaks that did not need a streak freeze.
+func updateRepStreaks(rt *router.Router) ([]repdb.RepStreakStatus, error) {
+	statuses, err := db.Reps.GetStreakStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	unfrozen := make([]repdb.RepStreakStatus, 0, len(statuses))
+	for _, s := range statuses {
+		if !applyStreakFreeze(rt, s) {
+			unfrozen = append(unfrozen, s)
+		}
+	}
+
+	_, err = db.Reps.UpdateRepStreaks(getRepStreakCleanupWindow())
+	return unfrozen, err
+}
+
+// streakExpiry returns when a rep streak expires, along with the user who
+// needs to rep the other to stop it from expiring. This is the user whose
+// last rep falls out of the streak window first. If a streak freeze is
+// protecting the streak, it expires once the freeze ends.
+func streakExpiry(
+	status repdb.RepStreakStatus) (expiry time.Time, userID discord.UserID) {
+
+	for _, id := range []discord.UserID{status.UserID1, status.UserID2} {
+		lastRep := status.FirstRep
+		if t := status.LastRep(id); t != nil {
+			lastRep = *t
+		}
+
+		userExpiry := getStreakExpiry(lastRep, repLocation(id))
+		if !userID.IsValid() || userExpiry.Before(expiry) {
+			expiry, userID = userExpiry, id
+		}
+	}
+
+	if status.FrozenUntil != nil && status.FrozenUntil.After(expiry) {
+		expiry = *status.FrozenUntil
+	}
+
+	return expiry, userID
+}
+
+// applyStreakFreeze uses a streak freeze to save a rep streak that has
+// expired, if the user who missed their rep has one, giving them until the
+// next rep reset to rep the other user. It returns whether a freeze was used.
+func applyStreakFreeze(rt *router.Router, status repdb.RepStreakStatus) bool {
+	if status.Days() < 1 {
+		return false
+	}
+
+	expiry, userID := streakExpiry(status)
+	if time.Now().Before(expiry) {
+		return false
+	}
+
+	until := getNextRepReset(expiry, repLocation(userID))
+	if !time.Now().Before(until) {
+		return false
+	}
+
+	used, err := db.Reps.UseStreakFreeze(status.RepStreak, userID, until)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+	if !used {
+		return false
+	}
+
+	otherUserID := status.OtherUser(userID)
+	days := util.PluraliseWithCount("day", int64(status.Days()))
+	deadline := dctools.TimestampStyled(until, dctools.RelativeTime)
+
+	sendStreakDM(rt, userID, fmt.Sprintf(
+		"🧊 You missed a day, so a streak freeze was used to save your %s "+
+			"rep streak with %s! Rep them %s to keep it going.",
+		days, otherUserID.Mention(), deadline,
+	))
+	sendStreakDM(rt, otherUserID, fmt.Sprintf(
+		"🧊 %s missed a day, so they used a streak freeze to save your %s "+
+			"rep streak with them! They have until %s to rep you.",
+		userID.Mention(), days, deadline,
+	))
+
+	return true
+}
+
+// warnStreakExpiry warns both users in a rep streak that the streak is about
+// to expire, once for each expiry time.
+func warnStreakExpiry(rt *router.Router, status repdb.RepStreakStatus) {
+	if status.Days() < 1 {
+		return
+	}
+
+	expiry, userID := streakExpiry(status)
+
+	remaining := time.Until(expiry)
+	if remaining <= 0 || remaining > streakEndingHours {
+		return
+	}
+	if status.WarnedExpiry != nil && !status.WarnedExpiry.Before(expiry) {
+		return
+	}
+
+	_, err := db.Reps.SetStreakWarnedExpiry(status.RepStreak, expiry)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	otherUserID := status.OtherUser(userID)
+	days := util.PluraliseWithCount("day", int64(status.Days()))
+	deadline := dctools.TimestampStyled(expiry, dctools.RelativeTime)
+
+	freezeNotice := ""
+	freezes, err := db.Reps.GetStreakFreezes(userID)
+	if err != nil {
+		log.Println(err)
+	} else if freezes > 0 {
+		freezeNotice = " If you miss it, one of your streak freezes will be " +
+			"used to save the streak."
+	}
+
+	sendStreakDM(rt, userID, fmt.Sprintf(
+		"⌛ Your %s rep streak with %s expires %s! Give them a rep with "+
+			"`/rep give` to keep it going.%s",
+		days, otherUserID.Mention(), deadline, freezeNotice,
+	))
+	sendStreakDM(rt, otherUserID, fmt.Sprintf(
+		"⌛ Your %s rep streak with %s expires %s unless they give you a "+
+			"rep.",
+		days, userID.Mention(), deadline,
+	))
+}
+
+// awardStreakFreezes gives both users in a rep streak a streak freeze each
+// time the streak reaches a milestone, returning whether freezes were given.
+func awardStreakFreezes(streak repdb.RepStreak) bool {
+	milestone := streak.Days() / streakFreezeMilestoneDays
+	if milestone <= streak.FreezeMilestone {
+		return false
+	}
+
+	awarded, err := db.Reps.AwardStreakFreezes(
+		streak, milestone, maxStreakFreezes,
+	)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+
+	return awarded
+}
+
+func sendStreakDM(rt *router.Router, userID discord.UserID, content string) {
+	dmChannel, err := rt.State.CreatePrivateChannel(userID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	_, err = rt.State.SendMessageComplex(dmChannel.ID, api.SendMessageData{
+		Content:         content,
+		AllowedMentions: &api.AllowedMentions{},
+	})
+	if err != nil && !dctools.ErrCannotDM(err) {
+		log.Println(err)
+	}
+}
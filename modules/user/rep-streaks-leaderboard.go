@@ -36,7 +36,7 @@ func repStreaksLeaderboardExec(ctx router.CommandCtx) {
 		limit = 10
 	}
 
-	_, err := db.Reps.UpdateRepStreaks()
+	_, err := updateRepStreaks(ctx.Router)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while updating rep streaks")
@@ -24,14 +24,14 @@ var repStreaksListCommand = &router.SubCommand{
 func repStreaksListExec(ctx router.CommandCtx) {
 	senderID := ctx.Interaction.SenderID()
 
-	_, err := db.Reps.UpdateRepStreaks()
+	_, err := updateRepStreaks(ctx.Router)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while updating rep streaks")
 		return
 	}
 
-	streaks, err := db.Reps.GetUserStreaks(senderID)
+	streaks, err := db.Reps.GetUserStreakStatuses(senderID)
 	if err != nil {
 		log.Println(err)
 		ctx.RespondError("Error occurred while fetching your rep streaks.")
@@ -73,6 +73,16 @@ func repStreaksListExec(ctx router.CommandCtx) {
 	descriptionPages := util.PagedLines(streakList, 2048, 25)
 	footer := util.PluraliseWithCount("Ongoing Streak", int64(len(streaks)))
 
+	freezes, err := db.Reps.GetStreakFreezes(senderID)
+	if err != nil {
+		log.Println(err)
+	} else {
+		footer = dctools.SeparateEmbedFooter(
+			footer,
+			"🧊 "+util.PluraliseWithCount("Streak Freeze", int64(freezes)),
+		)
+	}
+
 	pages := make([]router.MessagePage, len(descriptionPages))
 	for i, description := range descriptionPages {
 		pageID := fmt.Sprintf("Page %d/%d", i+1, len(descriptionPages))
@@ -17,7 +17,8 @@ var repStreaksCommand = &router.SubCommandGroup{
 	Description: "Commands pertaining to user rep streaks",
 }
 
-func getStreakEmojiString(streak repdb.RepStreak) string {
+func getStreakEmojiString(status repdb.RepStreakStatus) string {
+	streak := status.RepStreak
 	emojis := []string{}
 	days := streak.Days()
 
@@ -43,10 +44,13 @@ func getStreakEmojiString(streak repdb.RepStreak) string {
 		emojis = append(emojis, topEmoji)
 	}
 
-	expTime, err := db.Reps.GetTimeToStreakExpiry(streak)
-	if err != nil {
-		log.Println(err)
-	} else if expTime < streakEndingHours {
+	if streak.Frozen() {
+		emojis = append(emojis, "🧊")
+	}
+
+	expiry, _ := streakExpiry(status)
+	remaining := time.Until(expiry)
+	if remaining > 0 && remaining < streakEndingHours {
 		emojis = append(emojis, "⌛")
 	}
 
@@ -1,26 +1,99 @@
 package user
 
-import "time"
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
 
-func getNextRepReset(lastRepTime time.Time) time.Time {
-	tomorrow := time.Now().Add(24 * time.Hour)
-	return time.Date(
-		tomorrow.Year(),
-		tomorrow.Month(),
-		tomorrow.Day(),
-		0,
-		0,
-		0,
-		0,
-		time.UTC,
-	)
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/config"
+	"github.com/twoscott/haseul-bot-2/utils/util"
+)
+
+// maxRepDayLength is the longest time since the start of the previous rep day
+// in any timezone, used where reps from users in different timezones are
+// compared.
+const maxRepDayLength = 48 * time.Hour
+
+// loadRepResetLocation returns the location of the configured rep reset
+// timezone, or UTC if it is not a valid timezone.
+func loadRepResetLocation() *time.Location {
+	timezone := config.GetInstance().Reps.ResetTimezone
+
+	loc, ok := util.LoadTimeZone(timezone)
+	if !ok {
+		log.Printf("Invalid rep reset timezone %q, using UTC\n", timezone)
+		return time.UTC
+	}
+
+	return loc
+}
+
+// repLocation returns the location whose midnight a user's daily reps reset
+// at. This is the user's own timezone if user timezones are enabled and they
+// have set one, otherwise it is the configured rep reset timezone.
+func repLocation(userID discord.UserID) *time.Location {
+	if !config.GetInstance().Reps.UserTimezones {
+		return repResetLocation
+	}
+
+	timezone, err := db.Users.GetTimezone(userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Println(err)
+		}
+		return repResetLocation
+	}
+
+	loc, ok := util.LoadTimeZone(timezone)
+	if !ok {
+		return repResetLocation
+	}
+
+	return loc
+}
+
+// startOfRepDay returns the time the rep day containing t started in the
+// provided location.
+func startOfRepDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func getNextRepReset(lastRepTime time.Time, loc *time.Location) time.Time {
+	return startOfRepDay(lastRepTime, loc).AddDate(0, 0, 1)
+}
+
+func getNextRepResetFromNow(loc *time.Location) time.Time {
+	return getNextRepReset(time.Now(), loc)
 }
 
-func getNextRepResetFromNow() time.Time {
-	return getNextRepReset(time.Now())
+func getRepCutoff(loc *time.Location) time.Time {
+	return startOfRepDay(time.Now(), loc)
+}
+
+// getRepStreakWindow returns the start of the previous rep day in the
+// provided location. A rep streak continues as long as both users have
+// repped each other since then.
+func getRepStreakWindow(loc *time.Location) time.Time {
+	return getRepCutoff(loc).AddDate(0, 0, -1)
+}
+
+// getRepStreakCleanupWindow returns the time rep streaks are cleared from if
+// the users have not both repped each other since. When users' timezones are
+// used, the longest possible streak window is used so that no streak is
+// cleared before it has expired in its users' timezones.
+func getRepStreakCleanupWindow() time.Time {
+	if config.GetInstance().Reps.UserTimezones {
+		return time.Now().Add(-maxRepDayLength)
+	}
+
+	return getRepStreakWindow(repResetLocation)
 }
 
-func getRepCutoff() time.Time {
-	now := time.Now()
-	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+// getStreakExpiry returns when a rep streak expires if a rep given at
+// lastRepTime in the provided location isn't followed by another.
+func getStreakExpiry(lastRepTime time.Time, loc *time.Location) time.Time {
+	return startOfRepDay(lastRepTime, loc).AddDate(0, 0, 2)
 }
@@ -0,0 +1,309 @@
+package user
+
+import (
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+	"github.com/twoscott/haseul-bot-2/router"
+)
+
+const (
+	voiceXPPerMinute   int64 = 3
+	voiceFlushInterval       = 5 * time.Minute
+)
+
+type voiceSessionKey struct {
+	guildID discord.GuildID
+	userID  discord.UserID
+}
+
+// voiceSession is a period of time a member has spent earning XP in a voice
+// channel.
+type voiceSession struct {
+	channelID discord.ChannelID
+	member    *discord.Member
+	since     time.Time
+}
+
+// voiceAward is the XP and voice time to be awarded to a member for a voice
+// session.
+type voiceAward struct {
+	key       voiceSessionKey
+	channelID discord.ChannelID
+	member    *discord.Member
+	duration  time.Duration
+}
+
+// voiceTracker tracks the voice sessions of members earning XP in voice
+// channels. Members earn XP while they are unmuted and undeafened in a
+// non-AFK voice channel with at least one other listener.
+type voiceTracker struct {
+	mu           sync.Mutex
+	sessions     map[voiceSessionKey]*voiceSession
+	flushStarted sync.Once
+}
+
+func newVoiceTracker() *voiceTracker {
+	return &voiceTracker{
+		sessions: make(map[voiceSessionKey]*voiceSession),
+	}
+}
+
+// Refresh starts voice sessions for members in a guild who are now earning
+// XP, and ends and awards the voice sessions of members who no longer are.
+func (t *voiceTracker) Refresh(rt *router.Router, guildID discord.GuildID) {
+	earning := earningVoiceMembers(rt, guildID)
+	now := time.Now()
+
+	var awards []voiceAward
+
+	t.mu.Lock()
+	for key, session := range t.sessions {
+		if key.guildID != guildID {
+			continue
+		}
+
+		vs, ok := earning[key.userID]
+		if ok && vs.ChannelID == session.channelID {
+			continue
+		}
+
+		awards = append(awards, session.end(key, now))
+		delete(t.sessions, key)
+	}
+
+	for userID, vs := range earning {
+		key := voiceSessionKey{guildID, userID}
+		if _, ok := t.sessions[key]; ok {
+			continue
+		}
+
+		t.sessions[key] = &voiceSession{
+			channelID: vs.ChannelID,
+			member:    vs.Member,
+			since:     now,
+		}
+	}
+	t.mu.Unlock()
+
+	for _, a := range awards {
+		awardVoiceXP(rt, a)
+	}
+}
+
+// Flush awards the XP earned so far in all ongoing voice sessions, so that
+// long sessions are not lost if the bot restarts.
+func (t *voiceTracker) Flush(rt *router.Router) {
+	now := time.Now()
+
+	var awards []voiceAward
+
+	t.mu.Lock()
+	for key, session := range t.sessions {
+		minutes := now.Sub(session.since).Truncate(time.Minute)
+		if minutes < time.Minute {
+			continue
+		}
+
+		awards = append(awards, voiceAward{
+			key:       key,
+			channelID: session.channelID,
+			member:    session.member,
+			duration:  minutes,
+		})
+		session.since = session.since.Add(minutes)
+	}
+	t.mu.Unlock()
+
+	for _, a := range awards {
+		awardVoiceXP(rt, a)
+	}
+}
+
+// RefreshAll refreshes the voice sessions in all guilds, picking up members
+// who were in voice channels before the bot started.
+func (t *voiceTracker) RefreshAll(rt *router.Router) {
+	guilds, err := rt.State.Guilds()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, g := range guilds {
+		t.Refresh(rt, g.ID)
+	}
+}
+
+// StartFlushJob starts the flush job in the background. Subsequent calls do
+// nothing.
+func (t *voiceTracker) StartFlushJob(
+	rt *router.Router, interval time.Duration) {
+
+	t.flushStarted.Do(func() {
+		go t.FlushJob(rt, interval)
+	})
+}
+
+// FlushJob refreshes the voice sessions in all guilds and awards the XP
+// earned in ongoing voice sessions at the provided interval.
+func (t *voiceTracker) FlushJob(rt *router.Router, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	for range ticker.C {
+		t.RefreshAll(rt)
+		t.Flush(rt)
+	}
+}
+
+func (s voiceSession) end(key voiceSessionKey, now time.Time) voiceAward {
+	return voiceAward{
+		key:       key,
+		channelID: s.channelID,
+		member:    s.member,
+		duration:  now.Sub(s.since),
+	}
+}
+
+func trackVoiceState(rt *router.Router, voiceState discord.VoiceState) {
+	if !voiceState.GuildID.IsValid() {
+		return
+	}
+
+	voiceSessions.Refresh(rt, voiceState.GuildID)
+}
+
+func startVoiceTracking(rt *router.Router, _ *gateway.ReadyEvent) {
+	voiceSessions.RefreshAll(rt)
+	voiceSessions.StartFlushJob(rt, voiceFlushInterval)
+}
+
+// earningVoiceMembers returns the voice states of the members in a guild who
+// are currently earning voice XP, mapped by user ID.
+func earningVoiceMembers(
+	rt *router.Router,
+	guildID discord.GuildID) map[discord.UserID]discord.VoiceState {
+
+	earning := make(map[discord.UserID]discord.VoiceState)
+
+	voiceStates, err := rt.State.VoiceStates(guildID)
+	if err != nil || len(voiceStates) < 1 {
+		return earning
+	}
+
+	guild, err := rt.State.Guild(guildID)
+	if err != nil {
+		log.Println(err)
+		return earning
+	}
+
+	listeners := make(map[discord.ChannelID]int)
+	humans := make([]discord.VoiceState, 0, len(voiceStates))
+	for _, vs := range voiceStates {
+		if !vs.ChannelID.IsValid() || vs.ChannelID == guild.AFKChannelID {
+			continue
+		}
+
+		if vs.Member == nil {
+			vs.Member, err = rt.State.Member(guildID, vs.UserID)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+		}
+		if vs.Member.User.Bot {
+			continue
+		}
+
+		humans = append(humans, vs)
+		if !vs.Deaf && !vs.SelfDeaf {
+			listeners[vs.ChannelID]++
+		}
+	}
+
+	for _, vs := range humans {
+		muted := vs.Mute || vs.SelfMute || vs.Suppress
+		deafened := vs.Deaf || vs.SelfDeaf
+		if muted || deafened {
+			continue
+		}
+
+		// the member counts as one of the channel's listeners, so another
+		// listener is needed for the member not to be alone.
+		if listeners[vs.ChannelID] < 2 {
+			continue
+		}
+
+		earning[vs.UserID] = vs
+	}
+
+	return earning
+}
+
+// awardVoiceXP adds the XP and voice time earned in a voice session to a
+// member's XP, applying the guild's XP rules.
+func awardVoiceXP(rt *router.Router, award voiceAward) {
+	seconds := int64(award.duration / time.Second)
+	if seconds < 1 {
+		return
+	}
+
+	levelConfig, err := levelConfigs.Config(award.key.guildID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	channelIDs := []discord.ChannelID{award.channelID}
+	channel, err := rt.State.Channel(award.channelID)
+	if err == nil && channel.ParentID.IsValid() {
+		channelIDs = append(channelIDs, channel.ParentID)
+	}
+
+	var roleIDs []discord.RoleID
+	if award.member != nil {
+		roleIDs = award.member.RoleIDs
+	}
+
+	multiplier := levelConfig.Multiplier(channelIDs, roleIDs)
+	if multiplier <= 0 {
+		return
+	}
+
+	if isWeekend(time.Now()) {
+		multiplier *= levelConfig.config.WeekendMultiplier
+	}
+
+	minutes := int64(award.duration / time.Minute)
+	xp := int64(math.Round(float64(minutes*voiceXPPerMinute) * multiplier))
+
+	totalXP, err := db.Levels.AddUserVoiceXP(
+		award.key.guildID, award.key.userID, xp, seconds,
+	)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if xp <= 0 || award.member == nil {
+		return
+	}
+
+	curve := levelConfig.config.Curve()
+	oldLevel := levelsdb.UserXP{XP: totalXP - xp}.Level(curve)
+	newLevel := levelsdb.UserXP{XP: totalXP}.Level(curve)
+	if newLevel > oldLevel {
+		handleLevelUp(
+			rt,
+			award.key.guildID,
+			award.channelID,
+			award.member.User,
+			award.member,
+			levelConfig.config,
+			newLevel,
+		)
+	}
+}
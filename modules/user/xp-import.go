@@ -0,0 +1,249 @@
+package user
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/twoscott/haseul-bot-2/database/levelsdb"
+)
+
+// importIDKeys and importXPKeys are the column names and JSON keys used for
+// user IDs and XP by the exports of other levelling bots, normalised by
+// normaliseImportKey.
+var (
+	importIDKeys = []string{"id", "userid", "user", "discordid", "memberid"}
+	importXPKeys = []string{"xp", "experience", "exp", "totalxp", "points"}
+	// importListKeys are the keys of JSON objects that hold the list of users
+	// in an export.
+	importListKeys = []string{"players", "users", "members", "leaderboard"}
+)
+
+var errNoImportColumns = errors.New("user ID and XP columns not found")
+
+// decodeXPImport decodes a CSV or JSON export of user XP from another
+// levelling bot, returning the users decoded and a description of each
+// invalid entry.
+func decodeXPImport(
+	fileName string, data []byte) ([]levelsdb.UserXP, []string, error) {
+
+	if strings.HasSuffix(strings.ToLower(fileName), ".csv") {
+		return decodeXPImportCSV(data)
+	}
+
+	return decodeXPImportJSON(data)
+}
+
+func decodeXPImportCSV(data []byte) ([]levelsdb.UserXP, []string, error) {
+	csvReader := csv.NewReader(bytes.NewReader(data))
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) < 1 {
+		return nil, nil, nil
+	}
+
+	idColumn, xpColumn := 0, 1
+	start := 0
+	if _, err := parseImportXP(recordField(records[0], xpColumn)); err != nil {
+		idColumn = findImportColumn(records[0], importIDKeys)
+		xpColumn = findImportColumn(records[0], importXPKeys)
+		if idColumn < 0 || xpColumn < 0 {
+			return nil, nil, errNoImportColumns
+		}
+		start = 1
+	}
+
+	var (
+		users   []levelsdb.UserXP
+		invalid []string
+	)
+	for i := start; i < len(records); i++ {
+		user, err := parseImportEntry(
+			recordField(records[i], idColumn),
+			recordField(records[i], xpColumn),
+		)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("Row %d: %s", i+1, err))
+			continue
+		}
+
+		users = append(users, *user)
+	}
+
+	return users, invalid, nil
+}
+
+func decodeXPImportJSON(data []byte) ([]levelsdb.UserXP, []string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var root any
+	err := decoder.Decode(&root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if obj, ok := root.(map[string]any); ok {
+		list, found := findImportList(obj)
+		if !found {
+			return decodeXPImportMap(obj)
+		}
+		root = list
+	}
+
+	entries, ok := root.([]any)
+	if !ok {
+		return nil, nil, errNoImportColumns
+	}
+
+	var (
+		users   []levelsdb.UserXP
+		invalid []string
+	)
+	for i, entry := range entries {
+		obj, ok := entry.(map[string]any)
+		if !ok {
+			invalid = append(
+				invalid, fmt.Sprintf("Entry %d: not an object", i+1),
+			)
+			continue
+		}
+
+		user, err := parseImportEntry(
+			findImportValue(obj, importIDKeys),
+			findImportValue(obj, importXPKeys),
+		)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("Entry %d: %s", i+1, err))
+			continue
+		}
+
+		users = append(users, *user)
+	}
+
+	return users, invalid, nil
+}
+
+// decodeXPImportMap decodes a JSON object mapping user IDs to XP.
+func decodeXPImportMap(
+	obj map[string]any) ([]levelsdb.UserXP, []string, error) {
+
+	var (
+		users   []levelsdb.UserXP
+		invalid []string
+	)
+	for id, value := range obj {
+		user, err := parseImportEntry(id, jsonString(value))
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", id, err))
+			continue
+		}
+
+		users = append(users, *user)
+	}
+
+	return users, invalid, nil
+}
+
+func parseImportEntry(id, xp string) (*levelsdb.UserXP, error) {
+	snowflake, err := discord.ParseSnowflake(strings.TrimSpace(id))
+	if err != nil || !snowflake.IsValid() {
+		return nil, fmt.Errorf("invalid user ID %q", id)
+	}
+
+	amount, err := parseImportXP(xp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XP %q", xp)
+	}
+
+	return &levelsdb.UserXP{UserID: discord.UserID(snowflake), XP: amount}, nil
+}
+
+func parseImportXP(xp string) (int64, error) {
+	xp = strings.ReplaceAll(strings.TrimSpace(xp), ",", "")
+
+	amount, err := strconv.ParseFloat(xp, 64)
+	if err != nil {
+		return 0, err
+	}
+	if amount < 0 || amount > maxAdminXP {
+		return 0, errors.New("XP out of range")
+	}
+
+	return int64(amount), nil
+}
+
+func findImportColumn(header []string, keys []string) int {
+	for i, column := range header {
+		for _, key := range keys {
+			if normaliseImportKey(column) == key {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func findImportList(obj map[string]any) ([]any, bool) {
+	for key, value := range obj {
+		for _, listKey := range importListKeys {
+			if normaliseImportKey(key) != listKey {
+				continue
+			}
+
+			list, ok := value.([]any)
+			return list, ok
+		}
+	}
+
+	return nil, false
+}
+
+func findImportValue(obj map[string]any, keys []string) string {
+	for key, value := range obj {
+		for _, k := range keys {
+			if normaliseImportKey(key) == k {
+				return jsonString(value)
+			}
+		}
+	}
+
+	return ""
+}
+
+// normaliseImportKey lowercases a key and removes separators, so that keys
+// such as "User ID", "user_id" and "userId" are treated the same.
+func normaliseImportKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(key)
+}
+
+func jsonString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+func recordField(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+
+	return record[i]
+}
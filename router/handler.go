@@ -59,10 +59,11 @@ func (h *Handler) MemberLeave(ev *gateway.GuildMemberRemoveEvent) {
 	h.Router.HandleMemberLeave(ev.User, ev.GuildID)
 }
 
+func (h *Handler) VoiceStateUpdate(ev *gateway.VoiceStateUpdateEvent) {
+	h.Router.HandleVoiceStateUpdate(ev.VoiceState)
+}
+
 func (h *Handler) MessageCreate(msg *gateway.MessageCreateEvent) {
-	if msg.Author.Bot {
-		return
-	}
 	if !dctools.IsUserMessage(msg.Type) {
 		return
 	}
@@ -76,6 +77,14 @@ func (h *Handler) MessageCreate(msg *gateway.MessageCreateEvent) {
 		return
 	}
 
+	if msg.Author.Bot {
+		me, err := h.Router.State.Me()
+		if err == nil && me.ID != msg.Author.ID {
+			go h.Router.HandleBotMessage(msg.Message, msg.Member)
+		}
+		return
+	}
+
 	go h.Router.HandleMessage(msg.Message, msg.Member)
 
 	if len(msg.Content) == 0 {
@@ -29,10 +29,12 @@ type (
 		memberJoinListeners    []MemberJoinListener
 		memberLeaveListeners   []MemberLeaveListener
 		messageCreateListeners []MessageCreateListener
+		botMessageListeners    []MessageCreateListener
 		messageDeleteListeners []MessageDeleteListener
 		messageUpdateListeners []MessageUpdateListener
 		mentionListeners       []MessageCreateListener
 		startupListeners       []ReadyListener
+		voiceStateListeners    []VoiceStateListener
 	}
 
 	CommandHandlers map[string]*CommandHandler
@@ -57,6 +59,7 @@ type (
 	MemberJoinListener  func(*Router, discord.Member, discord.GuildID)
 	MemberLeaveListener func(*Router, discord.User, discord.GuildID)
 	ReadyListener       func(*Router, *gateway.ReadyEvent)
+	VoiceStateListener  func(*Router, discord.VoiceState)
 )
 
 // New returns a new instance of Router.
@@ -293,6 +296,26 @@ func (rt *Router) HandleMessage(
 	}
 }
 
+// AddBotMessageHandler adds a function to receive all messages sent by bots
+// and webhooks, excluding messages sent by this bot.
+func (rt *Router) AddBotMessageHandler(
+	botMessageListener MessageCreateListener) {
+
+	rt.botMessageListeners = append(
+		rt.botMessageListeners, botMessageListener,
+	)
+}
+
+// HandleBotMessage routes a message create event sent by a bot or webhook to
+// all bot message listener functions registered to the router.
+func (rt *Router) HandleBotMessage(
+	msg discord.Message, member *discord.Member) {
+
+	for _, listener := range rt.botMessageListeners {
+		go listener(rt, msg, member)
+	}
+}
+
 // AddMessageDeleteHandler adds a function to receive all messages deleted.
 func (rt *Router) AddMessageDeleteHandler(
 	messageDeleteListener MessageDeleteListener) {
@@ -379,6 +402,21 @@ func (rt *Router) HandleMemberLeave(
 	}
 }
 
+// AddVoiceStateHandler adds a function to receive all voice state updates.
+func (rt *Router) AddVoiceStateHandler(
+	voiceStateListener VoiceStateListener) {
+
+	rt.voiceStateListeners = append(rt.voiceStateListeners, voiceStateListener)
+}
+
+// HandleVoiceStateUpdate routes a voice state update event to all listener
+// functions registered to the router.
+func (rt *Router) HandleVoiceStateUpdate(voiceState discord.VoiceState) {
+	for _, listener := range rt.voiceStateListeners {
+		go listener(rt, voiceState)
+	}
+}
+
 // AddStartupListener adds a function to receive all ready events.
 func (rt *Router) AddStartupListener(readyListener ReadyListener) {
 	rt.startupListeners = append(rt.startupListeners, readyListener)
@@ -23,6 +23,16 @@ func ErrUnknownChannel(err error) bool {
 	return httpErr.Code == 10003
 }
 
+// ErrUnknownMessage returns whether the error is an unknown message error.
+func ErrUnknownMessage(err error) bool {
+	httpErr := UnwrapHTTPError(err)
+	if httpErr == nil {
+		return false
+	}
+
+	return httpErr.Code == 10008
+}
+
 // ErrUnknownGuild returns whether the error is an unknown guild error.
 func ErrUnknownGuild(err error) bool {
 	httpErr := UnwrapHTTPError(err)
@@ -0,0 +1,221 @@
+package util
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	monthPattern = `(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|` +
+		`june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|` +
+		`nov(?:ember)?|dec(?:ember)?)\.?`
+	weekdayPattern = `(mon|tue(?:s)?|wed(?:nes)?|thu(?:r(?:s)?)?|fri|` +
+		`sat(?:ur)?|sun)(?:day)?`
+	ordinalPattern = `(?:st|nd|rd|th)?`
+
+	// defaultHour is the hour used when a date is given without a time.
+	defaultHour = 9
+	// tonightHour is the hour used for "tonight" when no time is given.
+	tonightHour = 20
+)
+
+var (
+	clockTimeRegex = regexp.MustCompile(
+		`\b(?:(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)|` +
+			`(?:at\s+)?(\d{1,2}):(\d{2})|(?:at\s+)?(noon|midday|midnight)|` +
+			`at\s+(\d{1,2}))\b`,
+	)
+	isoDateRegex   = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	monthDateRegex = regexp.MustCompile(
+		`^` + monthPattern + `\s+(\d{1,2})` + ordinalPattern +
+			`(?:,?\s+(\d{4}))?$`,
+	)
+	dateMonthRegex = regexp.MustCompile(
+		`^(\d{1,2})` + ordinalPattern + `\s+(?:of\s+)?` + monthPattern +
+			`(?:,?\s+(\d{4}))?$`,
+	)
+	weekdayRegex = regexp.MustCompile(
+		`^(?:(next|this)\s+)?` + weekdayPattern + `$`,
+	)
+	fillerRegex = regexp.MustCompile(`\b(?:at|on)\b|,`)
+)
+
+var (
+	monthPrefixes = map[string]time.Month{
+		"jan": time.January,
+		"feb": time.February,
+		"mar": time.March,
+		"apr": time.April,
+		"may": time.May,
+		"jun": time.June,
+		"jul": time.July,
+		"aug": time.August,
+		"sep": time.September,
+		"oct": time.October,
+		"nov": time.November,
+		"dec": time.December,
+	}
+	weekdayPrefixes = map[string]time.Weekday{
+		"sun": time.Sunday,
+		"mon": time.Monday,
+		"tue": time.Tuesday,
+		"wed": time.Wednesday,
+		"thu": time.Thursday,
+		"fri": time.Friday,
+		"sat": time.Saturday,
+	}
+)
+
+// clockTime represents a time of day.
+type clockTime struct {
+	hour   int
+	minute int
+}
+
+// ParseDateTime parses a human readable absolute time such as "tomorrow 9am",
+// "Oct 31 18:00" or "next friday" in the location of now. Dates given
+// without a time are set to 9am, and times given without a date are set to
+// their next occurrence.
+func ParseDateTime(input string, now time.Time) (time.Time, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	clock, input, ok := extractClockTime(input)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	input = fillerRegex.ReplaceAllString(input, " ")
+	input = strings.Join(strings.Fields(input), " ")
+
+	loc := now.Location()
+	year, month, day := now.Date()
+	date := func(y int, m time.Month, d int, c clockTime) time.Time {
+		return time.Date(y, m, d, c.hour, c.minute, 0, 0, loc)
+	}
+
+	withDefault := func(hour int) clockTime {
+		if clock != nil {
+			return *clock
+		}
+		return clockTime{hour: hour}
+	}
+
+	switch input {
+	case "":
+		if clock == nil {
+			return time.Time{}, false
+		}
+		t := date(year, month, day, *clock)
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, true
+	case "today":
+		return date(year, month, day, withDefault(defaultHour)), true
+	case "tonight":
+		return date(year, month, day, withDefault(tonightHour)), true
+	case "tomorrow", "tmrw", "tmr":
+		return date(year, month, day+1, withDefault(defaultHour)), true
+	}
+
+	if match := weekdayRegex.FindStringSubmatch(input); match != nil {
+		weekday := weekdayPrefixes[match[2][:3]]
+		days := (int(weekday) - int(now.Weekday()) + 7) % 7
+
+		t := date(year, month, day+days, withDefault(defaultHour))
+		if match[1] == "next" && days == 0 || !t.After(now) {
+			t = t.AddDate(0, 0, 7)
+		}
+		return t, true
+	}
+
+	if match := isoDateRegex.FindStringSubmatch(input); match != nil {
+		y, _ := strconv.Atoi(match[1])
+		m, _ := strconv.Atoi(match[2])
+		d, _ := strconv.Atoi(match[3])
+		if !validDate(y, time.Month(m), d) {
+			return time.Time{}, false
+		}
+		return date(y, time.Month(m), d, withDefault(defaultHour)), true
+	}
+
+	var monthName, dayString, yearString string
+	if match := monthDateRegex.FindStringSubmatch(input); match != nil {
+		monthName, dayString, yearString = match[1], match[2], match[3]
+	} else if match := dateMonthRegex.FindStringSubmatch(input); match != nil {
+		dayString, monthName, yearString = match[1], match[2], match[3]
+	} else {
+		return time.Time{}, false
+	}
+
+	m := monthPrefixes[monthName[:3]]
+	d, _ := strconv.Atoi(dayString)
+	y := year
+	if yearString != "" {
+		y, _ = strconv.Atoi(yearString)
+	}
+	if !validDate(y, m, d) {
+		return time.Time{}, false
+	}
+
+	t := date(y, m, d, withDefault(defaultHour))
+	if yearString == "" && !t.After(now) {
+		t = t.AddDate(1, 0, 0)
+	}
+
+	return t, true
+}
+
+// extractClockTime finds a time of day in the input, returning it along with
+// the input with the time removed. A nil time is returned if the input does
+// not contain a time of day.
+func extractClockTime(input string) (*clockTime, string, bool) {
+	match := clockTimeRegex.FindStringSubmatchIndex(input)
+	if match == nil {
+		return nil, input, true
+	}
+
+	group := func(i int) string {
+		if match[i*2] < 0 {
+			return ""
+		}
+		return input[match[i*2]:match[i*2+1]]
+	}
+
+	var hour, minute int
+	switch {
+	case group(3) != "":
+		hour, _ = strconv.Atoi(group(1))
+		minute, _ = strconv.Atoi(group(2))
+		if hour < 1 || hour > 12 {
+			return nil, input, false
+		}
+		hour %= 12
+		if group(3) == "pm" {
+			hour += 12
+		}
+	case group(4) != "":
+		hour, _ = strconv.Atoi(group(4))
+		minute, _ = strconv.Atoi(group(5))
+	case group(6) == "midnight":
+		hour = 0
+	case group(6) != "":
+		hour = 12
+	default:
+		hour, _ = strconv.Atoi(group(7))
+	}
+
+	if hour > 23 || minute > 59 {
+		return nil, input, false
+	}
+
+	remaining := input[:match[0]] + " " + input[match[1]:]
+	return &clockTime{hour, minute}, remaining, true
+}
+
+func validDate(year int, month time.Month, day int) bool {
+	return month >= time.January && month <= time.December &&
+		day >= 1 && day <= daysIn(year, month)
+}
@@ -16,7 +16,9 @@ var (
 	weeksRegex   = regexp.MustCompile(`(?i)(\d+)\s*(?:wk?|week)s?`)
 	daysRegex    = regexp.MustCompile(`(?i)(\d+)\s*(?:d|day)s?`)
 	hoursRegex   = regexp.MustCompile(`(?i)(\d+)\s*(?:hr?|hour)s?`)
-	minutesRegex = regexp.MustCompile(`(?i)(\d+)\s*(?:(?-i:m)|min(?:ute)?)s?`)
+	minutesRegex = regexp.MustCompile(
+		`(?i)(\d+)\s*(?:min(?:ute)?s?|(?-i:m)(?:[^o]|$))`,
+	)
 	secondsRegex = regexp.MustCompile(`(?i)(\d+)\s*(?:s(?:ec(?:ond)?)?)s?`)
 )
 